@@ -2,18 +2,19 @@ package discordapp
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
-
-	"github.com/kodishim/discordapp/discordapp/util"
 )
 
 // An application represents a Discord application.
+//
+// Secret is a Secret so it doesn't leak when the application is formatted or logged.
 type Application struct {
 	Bot    *Bot
-	Secret string
+	Secret Secret
 }
 
 // NewApplication creates & returns a pointer to a Discord application using the passed token & secret.
@@ -27,7 +28,34 @@ func NewApplication(token string, secret string) (*Application, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating new bot: %w", err)
 	}
-	return &Application{Bot: bot, Secret: secret}, nil
+	return &Application{Bot: bot, Secret: Secret(secret)}, nil
+}
+
+// NewApplicationWithID creates & returns a pointer to a Discord application using the passed token, secret & application ID without
+// making a request. VerifyKey can be "" if unknown.
+//
+// The token isn't checked until it's used, see Validate.
+func NewApplicationWithID(token string, secret string, applicationID Snowflake, verifyKey string) *Application {
+	return &Application{Bot: NewBotWithApplicationID(token, applicationID, verifyKey), Secret: Secret(secret)}
+}
+
+// Validate checks the application's bot token, see Bot.Validate.
+//
+// It returns the same errors as Bot.Validate.
+func (a *Application) Validate() error {
+	return a.Bot.Validate()
+}
+
+// AccessTokenResponse represents the response of Discord's API when exchanging a code for an access token.
+//
+// Webhook is only present if the ScopeWebhookIncoming scope was granted.
+type AccessTokenResponse struct {
+	AccessToken  string   `json:"access_token"`
+	TokenType    string   `json:"token_type"`
+	ExpiresIn    int      `json:"expires_in"`
+	RefreshToken string   `json:"refresh_token"`
+	Scope        string   `json:"scope"`
+	Webhook      *Webhook `json:"webhook"`
 }
 
 // FetchAccessToken fetches an access & refresh token using the passed code.
@@ -38,43 +66,56 @@ func NewApplication(token string, secret string) (*Application, error) {
 //   - ErrUnauthorized: Returned if authentication failed.
 //   - UnexpectedResponseError: Returned if an unexpected response was received.
 func (a *Application) FetchAccessToken(code string, redirectURI string) (accessToken string, refreshToken string, expiresIn int, err error) {
-	credByte := []byte(fmt.Sprintf("%s:%s", a.Bot.Application.ID, a.Secret))
+	respBody, err := a.FetchAccessTokenResponse(code, redirectURI)
+	if err != nil {
+		return
+	}
+	accessToken = respBody.AccessToken
+	refreshToken = respBody.RefreshToken
+	expiresIn = respBody.ExpiresIn
+	return
+}
+
+// FetchAccessTokenResponse exchanges the passed code for the full token response, including the webhook created when the ScopeWebhookIncoming scope is granted.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if authentication failed.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+func (a *Application) FetchAccessTokenResponse(code string, redirectURI string) (*AccessTokenResponse, error) {
+	applicationID, err := a.Bot.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	credByte := []byte(fmt.Sprintf("%s:%s", applicationID, a.Secret.Value()))
 	cred := base64.StdEncoding.EncodeToString(credByte)
 	formData := url.Values{}
-	formData.Set("client_id", a.Bot.Application.ID)
-	formData.Set("client_secret", a.Secret)
+	formData.Set("client_id", applicationID.String())
+	formData.Set("client_secret", a.Secret.Value())
 	formData.Set("grant_type", "authorization_code")
 	formData.Set("code", code)
 	formData.Set("redirect_uri", redirectURI)
-	req, err := http.NewRequest("POST", BaseDiscordAPIURL+"/oauth2/token", strings.NewReader(formData.Encode()))
+	req, err := http.NewRequest("POST", a.Bot.apiURL()+"/oauth2/token", strings.NewReader(formData.Encode()))
 	if err != nil {
-		err = fmt.Errorf("error forming request: %w", err)
-		return
+		return nil, fmt.Errorf("error forming request: %w", err)
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", cred))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	var respBody struct {
-		AccessToken  string `json:"access_token"`
-		RefreshToken string `json:"refresh_token"`
-		ExpiresIn    int    `json:"expires_in"`
-	}
-	resp, err := util.MakeRequest(req, nil, &respBody)
+	resp, err := makeRequest(req, a.Bot.httpClient(), a.Bot.apiURL(), nil)
 	if err != nil {
-		err = fmt.Errorf("error making request: %w", err)
-		return
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	if resp.Status != http.StatusOK {
 		if resp.Status == http.StatusUnauthorized {
-			err = ErrUnauthorized
-			return
+			return nil, ErrUnauthorized
 		}
-		err = &UnexpectedResponseError{resp}
-		return
+		return nil, &UnexpectedResponseError{resp}
 	}
-	accessToken = respBody.AccessToken
-	refreshToken = respBody.RefreshToken
-	expiresIn = respBody.ExpiresIn
-	return
+	var respBody AccessTokenResponse
+	err = json.Unmarshal(resp.Body, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling json: %w", err)
+	}
+	return &respBody, nil
 }
 
 // RefreshAccessToken exchanges the passed refresh token for a new access token & refresh token.
@@ -85,12 +126,16 @@ func (a *Application) FetchAccessToken(code string, redirectURI string) (accessT
 //   - ErrInvalidAccessToken: Returned if access token is invalid.
 //   - UnexpectedResponseError: Returned if an unexpected response was received.
 func (a *Application) RefreshAccessToken(refreshToken string) (newAccessToken string, newRefreshToken string, expiresIn int, err error) {
+	applicationID, err := a.Bot.applicationID()
+	if err != nil {
+		return
+	}
 	formData := url.Values{}
-	formData.Set("client_id", a.Bot.Application.ID)
-	formData.Set("client_secret", a.Secret)
+	formData.Set("client_id", applicationID.String())
+	formData.Set("client_secret", a.Secret.Value())
 	formData.Set("grant_type", "refresh_token")
 	formData.Set("refresh_token", refreshToken)
-	req, err := http.NewRequest(http.MethodPost, BaseDiscordAPIURL+"/oauth2/token", strings.NewReader(formData.Encode()))
+	req, err := http.NewRequest(http.MethodPost, a.Bot.apiURL()+"/oauth2/token", strings.NewReader(formData.Encode()))
 	if err != nil {
 		err = fmt.Errorf("error forming request: %w", err)
 		return
@@ -101,7 +146,7 @@ func (a *Application) RefreshAccessToken(refreshToken string) (newAccessToken st
 		RefreshToken string `json:"refresh_token"`
 		ExpiresIn    int    `json:"expires_in"`
 	}
-	resp, err := util.MakeRequest(req, nil, &respBody)
+	resp, err := makeRequest(req, a.Bot.httpClient(), a.Bot.apiURL(), &respBody)
 	if err != nil {
 		err = fmt.Errorf("error making request: %w", err)
 		return
@@ -0,0 +1,424 @@
+package discordapp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// AuditLogActionType represents the type of action that created an audit log entry.
+type AuditLogActionType int
+
+// Audit log action types
+const (
+	AuditLogActionGuildUpdate                             AuditLogActionType = 1
+	AuditLogActionChannelCreate                           AuditLogActionType = 10
+	AuditLogActionChannelUpdate                           AuditLogActionType = 11
+	AuditLogActionChannelDelete                           AuditLogActionType = 12
+	AuditLogActionChannelOverwriteCreate                  AuditLogActionType = 13
+	AuditLogActionChannelOverwriteUpdate                  AuditLogActionType = 14
+	AuditLogActionChannelOverwriteDelete                  AuditLogActionType = 15
+	AuditLogActionMemberKick                              AuditLogActionType = 20
+	AuditLogActionMemberPrune                             AuditLogActionType = 21
+	AuditLogActionMemberBanAdd                            AuditLogActionType = 22
+	AuditLogActionMemberBanRemove                         AuditLogActionType = 23
+	AuditLogActionMemberUpdate                            AuditLogActionType = 24
+	AuditLogActionMemberRoleUpdate                        AuditLogActionType = 25
+	AuditLogActionMemberMove                              AuditLogActionType = 26
+	AuditLogActionMemberDisconnect                        AuditLogActionType = 27
+	AuditLogActionBotAdd                                  AuditLogActionType = 28
+	AuditLogActionRoleCreate                              AuditLogActionType = 30
+	AuditLogActionRoleUpdate                              AuditLogActionType = 31
+	AuditLogActionRoleDelete                              AuditLogActionType = 32
+	AuditLogActionInviteCreate                            AuditLogActionType = 40
+	AuditLogActionInviteUpdate                            AuditLogActionType = 41
+	AuditLogActionInviteDelete                            AuditLogActionType = 42
+	AuditLogActionWebhookCreate                           AuditLogActionType = 50
+	AuditLogActionWebhookUpdate                           AuditLogActionType = 51
+	AuditLogActionWebhookDelete                           AuditLogActionType = 52
+	AuditLogActionEmojiCreate                             AuditLogActionType = 60
+	AuditLogActionEmojiUpdate                             AuditLogActionType = 61
+	AuditLogActionEmojiDelete                             AuditLogActionType = 62
+	AuditLogActionMessageDelete                           AuditLogActionType = 72
+	AuditLogActionMessageBulkDelete                       AuditLogActionType = 73
+	AuditLogActionMessagePin                              AuditLogActionType = 74
+	AuditLogActionMessageUnpin                            AuditLogActionType = 75
+	AuditLogActionIntegrationCreate                       AuditLogActionType = 80
+	AuditLogActionIntegrationUpdate                       AuditLogActionType = 81
+	AuditLogActionIntegrationDelete                       AuditLogActionType = 82
+	AuditLogActionStageInstanceCreate                     AuditLogActionType = 83
+	AuditLogActionStageInstanceUpdate                     AuditLogActionType = 84
+	AuditLogActionStageInstanceDelete                     AuditLogActionType = 85
+	AuditLogActionStickerCreate                           AuditLogActionType = 90
+	AuditLogActionStickerUpdate                           AuditLogActionType = 91
+	AuditLogActionStickerDelete                           AuditLogActionType = 92
+	AuditLogActionGuildScheduledEventCreate               AuditLogActionType = 100
+	AuditLogActionGuildScheduledEventUpdate               AuditLogActionType = 101
+	AuditLogActionGuildScheduledEventDelete               AuditLogActionType = 102
+	AuditLogActionThreadCreate                            AuditLogActionType = 110
+	AuditLogActionThreadUpdate                            AuditLogActionType = 111
+	AuditLogActionThreadDelete                            AuditLogActionType = 112
+	AuditLogActionApplicationCommandPermissionUpdate      AuditLogActionType = 121
+	AuditLogActionSoundboardSoundCreate                   AuditLogActionType = 130
+	AuditLogActionSoundboardSoundUpdate                   AuditLogActionType = 131
+	AuditLogActionSoundboardSoundDelete                   AuditLogActionType = 132
+	AuditLogActionAutoModerationRuleCreate                AuditLogActionType = 140
+	AuditLogActionAutoModerationRuleUpdate                AuditLogActionType = 141
+	AuditLogActionAutoModerationRuleDelete                AuditLogActionType = 142
+	AuditLogActionAutoModerationBlockMessage              AuditLogActionType = 143
+	AuditLogActionAutoModerationFlagToChannel             AuditLogActionType = 144
+	AuditLogActionAutoModerationUserCommunicationDisabled AuditLogActionType = 145
+	AuditLogActionAutoModerationQuarantineUser            AuditLogActionType = 146
+	AuditLogActionCreatorMonetizationRequestCreated       AuditLogActionType = 150
+	AuditLogActionCreatorMonetizationTermsAccepted        AuditLogActionType = 151
+	AuditLogActionOnboardingPromptCreate                  AuditLogActionType = 163
+	AuditLogActionOnboardingPromptUpdate                  AuditLogActionType = 164
+	AuditLogActionOnboardingPromptDelete                  AuditLogActionType = 165
+	AuditLogActionOnboardingCreate                        AuditLogActionType = 166
+	AuditLogActionOnboardingUpdate                        AuditLogActionType = 167
+	AuditLogActionHomeSettingsCreate                      AuditLogActionType = 190
+	AuditLogActionHomeSettingsUpdate                      AuditLogActionType = 191
+)
+
+var auditLogActionNames = map[AuditLogActionType]string{
+	AuditLogActionGuildUpdate:                             "GUILD_UPDATE",
+	AuditLogActionChannelCreate:                           "CHANNEL_CREATE",
+	AuditLogActionChannelUpdate:                           "CHANNEL_UPDATE",
+	AuditLogActionChannelDelete:                           "CHANNEL_DELETE",
+	AuditLogActionChannelOverwriteCreate:                  "CHANNEL_OVERWRITE_CREATE",
+	AuditLogActionChannelOverwriteUpdate:                  "CHANNEL_OVERWRITE_UPDATE",
+	AuditLogActionChannelOverwriteDelete:                  "CHANNEL_OVERWRITE_DELETE",
+	AuditLogActionMemberKick:                              "MEMBER_KICK",
+	AuditLogActionMemberPrune:                             "MEMBER_PRUNE",
+	AuditLogActionMemberBanAdd:                            "MEMBER_BAN_ADD",
+	AuditLogActionMemberBanRemove:                         "MEMBER_BAN_REMOVE",
+	AuditLogActionMemberUpdate:                            "MEMBER_UPDATE",
+	AuditLogActionMemberRoleUpdate:                        "MEMBER_ROLE_UPDATE",
+	AuditLogActionMemberMove:                              "MEMBER_MOVE",
+	AuditLogActionMemberDisconnect:                        "MEMBER_DISCONNECT",
+	AuditLogActionBotAdd:                                  "BOT_ADD",
+	AuditLogActionRoleCreate:                              "ROLE_CREATE",
+	AuditLogActionRoleUpdate:                              "ROLE_UPDATE",
+	AuditLogActionRoleDelete:                              "ROLE_DELETE",
+	AuditLogActionInviteCreate:                            "INVITE_CREATE",
+	AuditLogActionInviteUpdate:                            "INVITE_UPDATE",
+	AuditLogActionInviteDelete:                            "INVITE_DELETE",
+	AuditLogActionWebhookCreate:                           "WEBHOOK_CREATE",
+	AuditLogActionWebhookUpdate:                           "WEBHOOK_UPDATE",
+	AuditLogActionWebhookDelete:                           "WEBHOOK_DELETE",
+	AuditLogActionEmojiCreate:                             "EMOJI_CREATE",
+	AuditLogActionEmojiUpdate:                             "EMOJI_UPDATE",
+	AuditLogActionEmojiDelete:                             "EMOJI_DELETE",
+	AuditLogActionMessageDelete:                           "MESSAGE_DELETE",
+	AuditLogActionMessageBulkDelete:                       "MESSAGE_BULK_DELETE",
+	AuditLogActionMessagePin:                              "MESSAGE_PIN",
+	AuditLogActionMessageUnpin:                            "MESSAGE_UNPIN",
+	AuditLogActionIntegrationCreate:                       "INTEGRATION_CREATE",
+	AuditLogActionIntegrationUpdate:                       "INTEGRATION_UPDATE",
+	AuditLogActionIntegrationDelete:                       "INTEGRATION_DELETE",
+	AuditLogActionStageInstanceCreate:                     "STAGE_INSTANCE_CREATE",
+	AuditLogActionStageInstanceUpdate:                     "STAGE_INSTANCE_UPDATE",
+	AuditLogActionStageInstanceDelete:                     "STAGE_INSTANCE_DELETE",
+	AuditLogActionStickerCreate:                           "STICKER_CREATE",
+	AuditLogActionStickerUpdate:                           "STICKER_UPDATE",
+	AuditLogActionStickerDelete:                           "STICKER_DELETE",
+	AuditLogActionGuildScheduledEventCreate:               "GUILD_SCHEDULED_EVENT_CREATE",
+	AuditLogActionGuildScheduledEventUpdate:               "GUILD_SCHEDULED_EVENT_UPDATE",
+	AuditLogActionGuildScheduledEventDelete:               "GUILD_SCHEDULED_EVENT_DELETE",
+	AuditLogActionThreadCreate:                            "THREAD_CREATE",
+	AuditLogActionThreadUpdate:                            "THREAD_UPDATE",
+	AuditLogActionThreadDelete:                            "THREAD_DELETE",
+	AuditLogActionApplicationCommandPermissionUpdate:      "APPLICATION_COMMAND_PERMISSION_UPDATE",
+	AuditLogActionSoundboardSoundCreate:                   "SOUNDBOARD_SOUND_CREATE",
+	AuditLogActionSoundboardSoundUpdate:                   "SOUNDBOARD_SOUND_UPDATE",
+	AuditLogActionSoundboardSoundDelete:                   "SOUNDBOARD_SOUND_DELETE",
+	AuditLogActionAutoModerationRuleCreate:                "AUTO_MODERATION_RULE_CREATE",
+	AuditLogActionAutoModerationRuleUpdate:                "AUTO_MODERATION_RULE_UPDATE",
+	AuditLogActionAutoModerationRuleDelete:                "AUTO_MODERATION_RULE_DELETE",
+	AuditLogActionAutoModerationBlockMessage:              "AUTO_MODERATION_BLOCK_MESSAGE",
+	AuditLogActionAutoModerationFlagToChannel:             "AUTO_MODERATION_FLAG_TO_CHANNEL",
+	AuditLogActionAutoModerationUserCommunicationDisabled: "AUTO_MODERATION_USER_COMMUNICATION_DISABLED",
+	AuditLogActionAutoModerationQuarantineUser:            "AUTO_MODERATION_QUARANTINE_USER",
+	AuditLogActionCreatorMonetizationRequestCreated:       "CREATOR_MONETIZATION_REQUEST_CREATED",
+	AuditLogActionCreatorMonetizationTermsAccepted:        "CREATOR_MONETIZATION_TERMS_ACCEPTED",
+	AuditLogActionOnboardingPromptCreate:                  "ONBOARDING_PROMPT_CREATE",
+	AuditLogActionOnboardingPromptUpdate:                  "ONBOARDING_PROMPT_UPDATE",
+	AuditLogActionOnboardingPromptDelete:                  "ONBOARDING_PROMPT_DELETE",
+	AuditLogActionOnboardingCreate:                        "ONBOARDING_CREATE",
+	AuditLogActionOnboardingUpdate:                        "ONBOARDING_UPDATE",
+	AuditLogActionHomeSettingsCreate:                      "HOME_SETTINGS_CREATE",
+	AuditLogActionHomeSettingsUpdate:                      "HOME_SETTINGS_UPDATE",
+}
+
+// String returns the name of the action type as used in Discord's documentation, for example MEMBER_KICK.
+func (t AuditLogActionType) String() string {
+	name, ok := auditLogActionNames[t]
+	if !ok {
+		return "UNKNOWN_" + strconv.Itoa(int(t))
+	}
+	return name
+}
+
+// AuditLog represents an audit log object returned by Discord's API.
+//
+// Users, Webhooks, Threads & GuildScheduledEvents contain the objects referenced by the entries.
+type AuditLog struct {
+	AuditLogEntries      []AuditLogEntry  `json:"audit_log_entries"`
+	Users                []MemberUser     `json:"users"`
+	Webhooks             []Webhook        `json:"webhooks"`
+	Threads              []Channel        `json:"threads"`
+	GuildScheduledEvents []ScheduledEvent `json:"guild_scheduled_events"`
+}
+
+// AuditLogEntry represents a single administrative action in a guild's audit log.
+//
+// UserID is nil for actions not performed by a user. Reason is the reason passed in the X-Audit-Log-Reason header, if any.
+type AuditLogEntry struct {
+	ID         Snowflake             `json:"id"`
+	TargetID   *Snowflake            `json:"target_id"`
+	Changes    []AuditLogChange      `json:"changes"`
+	UserID     *Snowflake            `json:"user_id"`
+	ActionType AuditLogActionType    `json:"action_type"`
+	Options    *AuditLogEntryOptions `json:"options"`
+	Reason     string                `json:"reason"`
+}
+
+// AuditLogEntryOptions represents the additional info included with certain action types.
+type AuditLogEntryOptions struct {
+	ApplicationID                 Snowflake `json:"application_id"`
+	AutoModerationRuleName        string    `json:"auto_moderation_rule_name"`
+	AutoModerationRuleTriggerType string    `json:"auto_moderation_rule_trigger_type"`
+	ChannelID                     Snowflake `json:"channel_id"`
+	Count                         string    `json:"count"`
+	DeleteMemberDays              string    `json:"delete_member_days"`
+	ID                            Snowflake `json:"id"`
+	MembersRemoved                string    `json:"members_removed"`
+	MessageID                     Snowflake `json:"message_id"`
+	RoleName                      string    `json:"role_name"`
+	Type                          string    `json:"type"`
+	IntegrationType               string    `json:"integration_type"`
+}
+
+// AuditLogChange represents a change made to a single field of the entry's target.
+//
+// OldValue & NewValue are the raw JSON values as their type depends on Key. OldValue is nil for created fields & NewValue is nil for removed fields.
+type AuditLogChange struct {
+	Key      string          `json:"key"`
+	OldValue json.RawMessage `json:"old_value,omitempty"`
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+}
+
+// Common audit log change keys
+const (
+	AuditLogChangeKeyName                       = "name"
+	AuditLogChangeKeyNick                       = "nick"
+	AuditLogChangeKeyTopic                      = "topic"
+	AuditLogChangeKeyPermissions                = "permissions"
+	AuditLogChangeKeyPermissionOverwrites       = "permission_overwrites"
+	AuditLogChangeKeyRoleAdd                    = "$add"
+	AuditLogChangeKeyRoleRemove                 = "$remove"
+	AuditLogChangeKeyAllow                      = "allow"
+	AuditLogChangeKeyDeny                       = "deny"
+	AuditLogChangeKeyCode                       = "code"
+	AuditLogChangeKeyChannelID                  = "channel_id"
+	AuditLogChangeKeyCommunicationDisabledUntil = "communication_disabled_until"
+	AuditLogChangeKeyMute                       = "mute"
+	AuditLogChangeKeyDeaf                       = "deaf"
+	AuditLogChangeKeyOwnerID                    = "owner_id"
+	AuditLogChangeKeyVanityURLCode              = "vanity_url_code"
+)
+
+// Decode unmarshals the change's old & new values into oldValue & newValue. Either can be nil to skip decoding that value.
+//
+// Values that are absent from the change are left untouched.
+func (c *AuditLogChange) Decode(oldValue any, newValue any) error {
+	if oldValue != nil && len(c.OldValue) > 0 {
+		err := json.Unmarshal(c.OldValue, oldValue)
+		if err != nil {
+			return fmt.Errorf("error unmarshaling old value of %s: %w", c.Key, err)
+		}
+	}
+	if newValue != nil && len(c.NewValue) > 0 {
+		err := json.Unmarshal(c.NewValue, newValue)
+		if err != nil {
+			return fmt.Errorf("error unmarshaling new value of %s: %w", c.Key, err)
+		}
+	}
+	return nil
+}
+
+// ChangesByKey returns the entry's changes keyed by their change key.
+func (e *AuditLogEntry) ChangesByKey() map[string]AuditLogChange {
+	changes := make(map[string]AuditLogChange, len(e.Changes))
+	for _, change := range e.Changes {
+		changes[change.Key] = change
+	}
+	return changes
+}
+
+// AuditLogParams represents the filters used when fetching a guild's audit log. Zero values are not sent.
+//
+// Before & After are entry IDs. Limit can be between 1 & 100, Discord defaults to 50.
+type AuditLogParams struct {
+	UserID     Snowflake
+	ActionType AuditLogActionType
+	Before     Snowflake
+	After      Snowflake
+	Limit      int
+}
+
+func (p *AuditLogParams) query() url.Values {
+	query := url.Values{}
+	if p.UserID != 0 {
+		query.Set("user_id", p.UserID.String())
+	}
+	if p.ActionType != 0 {
+		query.Set("action_type", strconv.Itoa(int(p.ActionType)))
+	}
+	if p.Before != 0 {
+		query.Set("before", p.Before.String())
+	}
+	if p.After != 0 {
+		query.Set("after", p.After.String())
+	}
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+// FetchGuildAuditLog fetches a page of the audit log of the guild with the passed guild ID using the passed filters.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to view the audit log.
+func (b *Bot) FetchGuildAuditLog(guildID Snowflake, params AuditLogParams) (*AuditLog, error) {
+	link := b.apiURL() + "/guilds/" + guildID.String() + "/audit-logs"
+	query := params.query()
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var auditLog AuditLog
+	resp, err := b.Request(req, &auditLog)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &auditLog, nil
+}
+
+// AuditLogIterator iterates over every entry of a guild's audit log matching a set of filters, fetching pages as needed.
+//
+//	it := bot.AuditLogIterator(guildID, discordapp.AuditLogParams{})
+//	for it.Next() {
+//		entry := it.Entry()
+//	}
+//	if it.Err() != nil {
+//		...
+//	}
+type AuditLogIterator struct {
+	bot     *Bot
+	guildID Snowflake
+	params  AuditLogParams
+	page    []AuditLogEntry
+	entry   AuditLogEntry
+	done    bool
+	err     error
+}
+
+// AuditLogIterator returns an iterator over the audit log of the guild with the passed guild ID.
+//
+// If params.After is set entries are iterated from oldest to newest starting after that entry, otherwise from newest to oldest.
+// params.Limit is used as the page size.
+func (b *Bot) AuditLogIterator(guildID Snowflake, params AuditLogParams) *AuditLogIterator {
+	return &AuditLogIterator{bot: b, guildID: guildID, params: params}
+}
+
+// Next advances the iterator to the next entry. It returns false when there are no more entries or an error occurred.
+func (it *AuditLogIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.page) == 0 {
+		if it.done {
+			return false
+		}
+		auditLog, err := it.bot.FetchGuildAuditLog(it.guildID, it.params)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		entries := auditLog.AuditLogEntries
+		if len(entries) == 0 {
+			it.done = true
+			return false
+		}
+		limit := it.params.Limit
+		if limit <= 0 {
+			limit = 50
+		}
+		if len(entries) < limit {
+			it.done = true
+		}
+		oldest, newest := entries[0].ID, entries[0].ID
+		for _, entry := range entries {
+			if entry.ID < oldest {
+				oldest = entry.ID
+			}
+			if newest < entry.ID {
+				newest = entry.ID
+			}
+		}
+		if it.params.After != 0 {
+			it.params.After = newest
+			sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+		} else {
+			it.params.Before = oldest
+			sort.Slice(entries, func(i, j int) bool { return entries[j].ID < entries[i].ID })
+		}
+		it.page = entries
+	}
+	it.entry = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Entry returns the current entry.
+func (it *AuditLogIterator) Entry() AuditLogEntry {
+	return it.entry
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *AuditLogIterator) Err() error {
+	return it.err
+}
+
+// setAuditLogReason sets the reason shown in the guild's audit log for the action performed by the request. Empty reasons are not sent.
+func setAuditLogReason(req *http.Request, reason string) {
+	if reason == "" {
+		return
+	}
+	req.Header.Set("X-Audit-Log-Reason", url.PathEscape(reason))
+}
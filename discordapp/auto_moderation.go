@@ -0,0 +1,362 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Auto moderation trigger types
+const (
+	AutoModerationTriggerTypeKeyword       = 1
+	AutoModerationTriggerTypeSpam          = 3
+	AutoModerationTriggerTypeKeywordPreset = 4
+	AutoModerationTriggerTypeMentionSpam   = 5
+	AutoModerationTriggerTypeMemberProfile = 6
+)
+
+// Auto moderation event types
+const (
+	AutoModerationEventTypeMessageSend  = 1
+	AutoModerationEventTypeMemberUpdate = 2
+)
+
+// Auto moderation keyword preset types
+const (
+	AutoModerationKeywordPresetProfanity     = 1
+	AutoModerationKeywordPresetSexualContent = 2
+	AutoModerationKeywordPresetSlurs         = 3
+)
+
+// Auto moderation action types
+const (
+	AutoModerationActionTypeBlockMessage           = 1
+	AutoModerationActionTypeSendAlertMessage       = 2
+	AutoModerationActionTypeTimeout                = 3
+	AutoModerationActionTypeBlockMemberInteraction = 4
+)
+
+// AutoModerationRule represents an auto moderation rule object returned by Discord's API.
+type AutoModerationRule struct {
+	ID              Snowflake                     `json:"id"`
+	GuildID         Snowflake                     `json:"guild_id"`
+	Name            string                        `json:"name"`
+	CreatorID       Snowflake                     `json:"creator_id"`
+	EventType       int                           `json:"event_type"`
+	TriggerType     int                           `json:"trigger_type"`
+	TriggerMetadata AutoModerationTriggerMetadata `json:"trigger_metadata"`
+	Actions         []AutoModerationAction        `json:"actions"`
+	Enabled         bool                          `json:"enabled"`
+	ExemptRoles     []Snowflake                   `json:"exempt_roles"`
+	ExemptChannels  []Snowflake                   `json:"exempt_channels"`
+}
+
+// AutoModerationTriggerMetadata represents the additional data used to determine whether a rule should be triggered.
+//
+// Which fields apply depends on the rule's trigger type:
+//   - KeywordFilter & RegexPatterns: keyword & member profile.
+//   - Presets: keyword preset.
+//   - AllowList: keyword, keyword preset & member profile.
+//   - MentionTotalLimit & MentionRaidProtectionEnabled: mention spam.
+type AutoModerationTriggerMetadata struct {
+	KeywordFilter                []string `json:"keyword_filter,omitempty"`
+	RegexPatterns                []string `json:"regex_patterns,omitempty"`
+	Presets                      []int    `json:"presets,omitempty"`
+	AllowList                    []string `json:"allow_list,omitempty"`
+	MentionTotalLimit            int      `json:"mention_total_limit,omitempty"`
+	MentionRaidProtectionEnabled bool     `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+// AutoModerationAction represents an action taken when a rule is triggered.
+type AutoModerationAction struct {
+	Type     int                           `json:"type"`
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+}
+
+// AutoModerationActionMetadata represents the additional data used when an action is executed.
+//
+// ChannelID applies to send alert message actions, DurationSeconds to timeout actions & CustomMessage to block message actions.
+type AutoModerationActionMetadata struct {
+	ChannelID       Snowflake `json:"channel_id,omitempty"`
+	DurationSeconds int       `json:"duration_seconds,omitempty"`
+	CustomMessage   string    `json:"custom_message,omitempty"`
+}
+
+// CreateAutoModerationRuleParams represents the options used when creating an auto moderation rule.
+type CreateAutoModerationRuleParams struct {
+	Name            string                         `json:"name"`
+	EventType       int                            `json:"event_type"`
+	TriggerType     int                            `json:"trigger_type"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         []AutoModerationAction         `json:"actions"`
+	Enabled         bool                           `json:"enabled"`
+	ExemptRoles     []Snowflake                    `json:"exempt_roles,omitempty"`
+	ExemptChannels  []Snowflake                    `json:"exempt_channels,omitempty"`
+}
+
+// ModifyAutoModerationRuleParams represents the fields that can be changed on an auto moderation rule. Nil fields are left unchanged.
+type ModifyAutoModerationRuleParams struct {
+	Name            *string                        `json:"name,omitempty"`
+	EventType       *int                           `json:"event_type,omitempty"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         *[]AutoModerationAction        `json:"actions,omitempty"`
+	Enabled         *bool                          `json:"enabled,omitempty"`
+	ExemptRoles     *[]Snowflake                   `json:"exempt_roles,omitempty"`
+	ExemptChannels  *[]Snowflake                   `json:"exempt_channels,omitempty"`
+}
+
+// AutoModerationMatch represents content matched by a rule.
+//
+// Pattern is the keyword or regex pattern that matched & Content is the matched part of the evaluated content.
+type AutoModerationMatch struct {
+	Pattern string
+	Content string
+}
+
+// Evaluate tests the passed content against the rule's keyword filter, regex patterns & allow list & returns the matches that would trigger the rule.
+//
+// Keywords follow Discord's wildcard semantics: "word" matches whole words, "word*" matches words starting with word, "*word" matches words
+// ending with word & "*word*" matches word anywhere. Matching is case insensitive. Matches that are covered by the allow list are not returned.
+// Word boundaries are determined using ASCII word characters, so results for other scripts may differ from Discord's.
+//
+// An error is also returned if a keyword is empty or a keyword or regex pattern can't be compiled. Empty matches of regex patterns are ignored.
+//
+// Possible Errors:
+//   - ErrUnsupportedTriggerType: Returned if the rule is not a keyword or member profile rule.
+func (r *AutoModerationRule) Evaluate(content string) ([]AutoModerationMatch, error) {
+	if r.TriggerType != AutoModerationTriggerTypeKeyword && r.TriggerType != AutoModerationTriggerTypeMemberProfile {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedTriggerType, r.TriggerType)
+	}
+	allowList := make([]*regexp.Regexp, len(r.TriggerMetadata.AllowList))
+	for i, allowed := range r.TriggerMetadata.AllowList {
+		pattern, err := keywordPattern(allowed, false)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling allow list keyword: %w", err)
+		}
+		re, err := regexp.Compile("^" + pattern + "$")
+		if err != nil {
+			return nil, fmt.Errorf("error compiling allow list keyword %q: %w", allowed, err)
+		}
+		allowList[i] = re
+	}
+	isAllowed := func(match string) bool {
+		for _, re := range allowList {
+			if re.MatchString(match) {
+				return true
+			}
+		}
+		return false
+	}
+	var matches []AutoModerationMatch
+	evaluate := func(pattern string, re *regexp.Regexp) {
+		for _, match := range re.FindAllString(content, -1) {
+			if match != "" && !isAllowed(match) {
+				matches = append(matches, AutoModerationMatch{Pattern: pattern, Content: match})
+			}
+		}
+	}
+	for _, keyword := range r.TriggerMetadata.KeywordFilter {
+		pattern, err := keywordPattern(keyword, true)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling keyword: %w", err)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling keyword %q: %w", keyword, err)
+		}
+		evaluate(keyword, re)
+	}
+	for _, pattern := range r.TriggerMetadata.RegexPatterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling regex pattern %q: %w", pattern, err)
+		}
+		evaluate(pattern, re)
+	}
+	return matches, nil
+}
+
+var wordCharPattern = regexp.MustCompile(`^\w$`)
+
+// keywordPattern converts an auto moderation keyword into a case insensitive regular expression.
+//
+// If boundaries is true the expression only matches on word boundaries unless the keyword has a leading or trailing wildcard. An error is
+// returned if the keyword is empty once its wildcards are removed, as it would match everything.
+func keywordPattern(keyword string, boundaries bool) (string, error) {
+	trimmed := keyword
+	prefix, suffix := "", ""
+	if strings.HasPrefix(trimmed, "*") {
+		trimmed = trimmed[1:]
+		prefix = `\w*`
+	}
+	if strings.HasSuffix(trimmed, "*") {
+		trimmed = trimmed[:len(trimmed)-1]
+		suffix = `\w*`
+	}
+	if trimmed == "" {
+		return "", fmt.Errorf("keyword %q is empty", keyword)
+	}
+	if first, _ := utf8.DecodeRuneInString(trimmed); prefix == "" && boundaries && wordCharPattern.MatchString(string(first)) {
+		prefix = `\b`
+	}
+	if last, _ := utf8.DecodeLastRuneInString(trimmed); suffix == "" && boundaries && wordCharPattern.MatchString(string(last)) {
+		suffix = `\b`
+	}
+	return "(?i)" + prefix + regexp.QuoteMeta(trimmed) + suffix, nil
+}
+
+// ListAutoModerationRules lists the auto moderation rules of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ListAutoModerationRules(guildID Snowflake) ([]AutoModerationRule, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/auto-moderation/rules", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var rules []AutoModerationRule
+	resp, err := b.Request(req, &rules)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return rules, nil
+}
+
+// FetchAutoModerationRule fetches the auto moderation rule with the passed rule ID from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrAutoModerationRuleNotFound: Returned if the rule does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) FetchAutoModerationRule(guildID Snowflake, ruleID Snowflake) (*AutoModerationRule, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/auto-moderation/rules/"+ruleID.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.autoModerationRuleRequest(req)
+}
+
+// CreateAutoModerationRule creates an auto moderation rule in the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) CreateAutoModerationRule(guildID Snowflake, params CreateAutoModerationRuleParams) (*AutoModerationRule, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/auto-moderation/rules", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.autoModerationRuleRequest(req)
+}
+
+// ModifyAutoModerationRule modifies the auto moderation rule with the passed rule ID in the guild with the passed guild ID & returns the updated rule.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrAutoModerationRuleNotFound: Returned if the rule does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ModifyAutoModerationRule(guildID Snowflake, ruleID Snowflake, params ModifyAutoModerationRuleParams) (*AutoModerationRule, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/auto-moderation/rules/"+ruleID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.autoModerationRuleRequest(req)
+}
+
+// DeleteAutoModerationRule deletes the auto moderation rule with the passed rule ID from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrAutoModerationRuleNotFound: Returned if the rule does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) DeleteAutoModerationRule(guildID Snowflake, ruleID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/guilds/"+guildID.String()+"/auto-moderation/rules/"+ruleID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+			if discordErr.code == 10066 {
+				return ErrAutoModerationRuleNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
+
+func (b *Bot) autoModerationRuleRequest(req *http.Request) (*AutoModerationRule, error) {
+	var rule AutoModerationRule
+	resp, err := b.Request(req, &rule)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 10066 {
+				return nil, ErrAutoModerationRuleNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &rule, nil
+}
@@ -1,51 +1,82 @@
 package discordapp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/kodishim/discordapp/discordapp/util"
 )
 
 // A bot represents a Discord bot.
+//
+// Token is a Secret so it doesn't leak when the bot is formatted or logged. Application is nil until the bot's application info is
+// loaded, see LoadApplication. ApplicationID & VerifyKey are set by the constructors or once the application info is loaded.
+//
+// State can be set to cache guilds, channels, roles, members & emojis, see NewState. Cache can be set to cache guilds, members &
+// application info, in memory or in a shared store, see NewLRUCache & NewStoreCache. When both are set, State is consulted first & wins
+// over Cache: a State entry is used until it's older than the State's MaxAge, while a Cache entry is used until its cache's TTL passes.
+// The bot's own writes update State & delete the affected Cache entries, e.g. creating a role deletes its guild's Cache entry, but
+// changes made by other processes only reach State through gateway events & Cache when its entries expire.
+//
+// BaseURL is the base URL of Discord's API the bot sends requests to, e.g. a discordtest.Server's URL. "" uses BaseDiscordAPIURL.
+// HTTPClient is the client the bot sends requests with. nil uses the package's HTTPClient.
 type Bot struct {
-	Token       string
-	Application *ApplicationInfo
+	Token         Secret
+	BaseURL       string
+	HTTPClient    *http.Client
+	ApplicationID Snowflake
+	VerifyKey     string
+	Application   *ApplicationInfo
+	State         *State
+	Cache         Cache
+	mu            sync.Mutex
+	loading       *applicationLoad
+}
+
+// applicationLoad is a fetch of the bot's application info started by LoadApplication, shared by the calls made while it's in flight.
+type applicationLoad struct {
+	done        chan struct{}
+	application *ApplicationInfo
+	err         error
 }
 
 // ApplicationInfo represents an application object returned by Discord's API
 type ApplicationInfo struct {
-	BotPublic           bool   `json:"bot_public"`
-	BotRequireCodeGrant bool   `json:"bot_require_code_grant"`
-	CoverImage          string `json:"cover_image"`
-	Description         string `json:"description"`
-	GuildID             string `json:"guild_id"`
-	Icon                string `json:"icon"`
-	ID                  string `json:"id"`
-	Name                string `json:"name"`
+	BotPublic           bool      `json:"bot_public"`
+	BotRequireCodeGrant bool      `json:"bot_require_code_grant"`
+	CoverImage          string    `json:"cover_image"`
+	Description         string    `json:"description"`
+	GuildID             Snowflake `json:"guild_id"`
+	Icon                string    `json:"icon"`
+	ID                  Snowflake `json:"id"`
+	Name                string    `json:"name"`
 	Owner               struct {
-		Avatar        string `json:"avatar"`
-		Discriminator string `json:"discriminator"`
-		Flags         int    `json:"flags"`
-		ID            string `json:"id"`
-		Username      string `json:"username"`
+		Avatar        string    `json:"avatar"`
+		Discriminator string    `json:"discriminator"`
+		Flags         int       `json:"flags"`
+		ID            Snowflake `json:"id"`
+		Username      string    `json:"username"`
 	} `json:"owner"`
-	PrimarySkuID string `json:"primary_sku_id"`
-	Slug         string `json:"slug"`
-	Summary      string `json:"summary"`
+	PrimarySkuID Snowflake `json:"primary_sku_id"`
+	Slug         string    `json:"slug"`
+	Summary      string    `json:"summary"`
 	Team         struct {
-		Icon    string `json:"icon"`
-		ID      string `json:"id"`
+		Icon    string    `json:"icon"`
+		ID      Snowflake `json:"id"`
 		Members []struct {
-			MembershipState int      `json:"membership_state"`
-			Permissions     []string `json:"permissions"`
-			TeamID          string   `json:"team_id"`
+			MembershipState int       `json:"membership_state"`
+			Permissions     []string  `json:"permissions"`
+			TeamID          Snowflake `json:"team_id"`
 			User            struct {
-				Avatar        string `json:"avatar"`
-				Discriminator string `json:"discriminator"`
-				ID            string `json:"id"`
-				Username      string `json:"username"`
+				Avatar        string    `json:"avatar"`
+				Discriminator string    `json:"discriminator"`
+				ID            Snowflake `json:"id"`
+				Username      string    `json:"username"`
 			} `json:"user"`
 		} `json:"members"`
 	} `json:"team"`
@@ -65,23 +96,186 @@ type DiscordErrorResponse struct {
 // Possible Errors:
 //   - ErrUnauthorized - Returned if the token is invalid.
 func NewBot(token string) (*Bot, error) {
+	return NewBotWithConfig(token, BotConfig{})
+}
+
+// NewBotWithCache creates & returns a pointer to a bot using the passed token & cache. The bot's application info is read from the
+// cache if present.
+//
+// It returns the same errors as NewBot.
+func NewBotWithCache(token string, cache Cache) (*Bot, error) {
+	return NewBotWithConfig(token, BotConfig{Cache: cache})
+}
+
+// BotConfig configures a bot created with NewBotWithConfig.
+type BotConfig struct {
+	// BaseURL is the base URL of Discord's API the bot sends requests to. "" uses BaseDiscordAPIURL.
+	BaseURL string
+	// HTTPClient is the client the bot sends requests with. nil uses the package's HTTPClient.
+	HTTPClient *http.Client
+	// Cache is the bot's Cache. Can be nil for no cache.
+	Cache Cache
+}
+
+// NewBotWithConfig creates & returns a pointer to a bot using the passed token & config. The bot's application info is read from the
+// config's cache if present.
+//
+// It returns the same errors as NewBot.
+func NewBotWithConfig(token string, config BotConfig) (*Bot, error) {
 	bot := &Bot{
-		Token:       token,
+		Token:       Secret(token),
+		BaseURL:     config.BaseURL,
+		HTTPClient:  config.HTTPClient,
 		Application: nil,
+		Cache:       config.Cache,
 	}
-	var err error
-	bot.Application, err = bot.FetchApplicationInfo()
+	_, err := bot.LoadApplication()
 	if err != nil {
 		return nil, fmt.Errorf("error fetching bot's application object: %w", err)
 	}
 	return bot, nil
 }
 
+// NewBotWithApplicationID creates & returns a pointer to a bot using the passed token & application ID without making a request.
+// VerifyKey can be "" if unknown.
+//
+// The token isn't checked & the bot's application info isn't loaded until it's needed, see Validate & LoadApplication.
+func NewBotWithApplicationID(token string, applicationID Snowflake, verifyKey string) *Bot {
+	return &Bot{
+		Token:         Secret(token),
+		ApplicationID: applicationID,
+		VerifyKey:     verifyKey,
+	}
+}
+
+// LoadApplication returns the bot's application info, fetching it & setting Application if it hasn't been loaded yet. Concurrent calls
+// share a single fetch, & the bot isn't locked while it's in flight.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - ErrApplicationMismatch: Returned if the token belongs to another application than ApplicationID.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+func (b *Bot) LoadApplication() (*ApplicationInfo, error) {
+	b.mu.Lock()
+	if b.Application != nil {
+		application := b.Application
+		b.mu.Unlock()
+		return application, nil
+	}
+	if load := b.loading; load != nil {
+		b.mu.Unlock()
+		<-load.done
+		return load.application, load.err
+	}
+	load := &applicationLoad{done: make(chan struct{})}
+	b.loading = load
+	b.mu.Unlock()
+
+	application, err := b.FetchApplicationInfo()
+	b.mu.Lock()
+	if err == nil {
+		err = b.checkApplication(application)
+	}
+	if err == nil {
+		b.setApplication(application)
+		load.application = application
+	}
+	load.err = err
+	b.loading = nil
+	b.mu.Unlock()
+	close(load.done)
+	return load.application, load.err
+}
+
+// Validate checks the bot's token by fetching its application info, bypassing the bot's Cache, & sets Application.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - ErrApplicationMismatch: Returned if the token belongs to another application than ApplicationID.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+func (b *Bot) Validate() error {
+	application, err := b.fetchApplicationInfo()
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	err = b.checkApplication(application)
+	if err != nil {
+		return err
+	}
+	if b.Cache != nil {
+		b.Cache.Set(ApplicationCacheKey(b.Token.Value()), *application)
+	}
+	b.setApplication(application)
+	return nil
+}
+
+// checkApplication returns ErrApplicationMismatch if the passed application info isn't the info of the bot's ApplicationID. b.mu must be
+// held.
+func (b *Bot) checkApplication(application *ApplicationInfo) error {
+	if b.ApplicationID != 0 && b.ApplicationID != application.ID {
+		return fmt.Errorf("%w: token belongs to %s, expected %s", ErrApplicationMismatch, application.ID, b.ApplicationID)
+	}
+	return nil
+}
+
+// setApplication sets the bot's application info & the fields taken from it. b.mu must be held.
+func (b *Bot) setApplication(application *ApplicationInfo) {
+	b.Application = application
+	b.ApplicationID = application.ID
+	if b.VerifyKey == "" {
+		b.VerifyKey = application.VerifyKey
+	}
+}
+
+// applicationID returns the bot's application ID, loading its application info if the ID isn't known.
+func (b *Bot) applicationID() (Snowflake, error) {
+	if id := b.knownApplicationID(); id != 0 {
+		return id, nil
+	}
+	application, err := b.LoadApplication()
+	if err != nil {
+		return 0, fmt.Errorf("error loading application info: %w", err)
+	}
+	return application.ID, nil
+}
+
+// apiURL returns the base URL the bot sends requests to.
+func (b *Bot) apiURL() string {
+	if b.BaseURL != "" {
+		return b.BaseURL
+	}
+	return BaseDiscordAPIURL
+}
+
+// httpClient returns the client the bot sends requests with.
+func (b *Bot) httpClient() *http.Client {
+	if b.HTTPClient != nil {
+		return b.HTTPClient
+	}
+	return HTTPClient
+}
+
+// knownApplicationID returns the bot's application ID without making a request, or 0 if it isn't known.
+func (b *Bot) knownApplicationID() Snowflake {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ApplicationID != 0 {
+		return b.ApplicationID
+	}
+	if b.Application != nil {
+		return b.Application.ID
+	}
+	return 0
+}
+
 // Request makes a request using the Bot's token for authentication & unmarshals the response into unmarshalTo if unmarshalTo is not nil.
 //
 // unmarshalTo should be a pointer or nil.
 //
-// If a response with status code less than 200 or greater than 299 is received an error is returned.
+// If a response with status code less than 200 or greater than 299 is received an error is returned. Rate limited requests are resent,
+// see MaxRequestRetries.
 //
 // Possible Errors:
 //   - ErrUnauthorized: Returned if the bot's token is invalid.
@@ -91,20 +285,34 @@ func (b *Bot) Request(req *http.Request, unmarshalTo any) (*util.Response, error
 	if req.Header == nil {
 		req.Header = http.Header{}
 	}
-	req.Header.Set("Authorization", "Bot "+b.Token)
-	resp, err := util.MakeRequest(req, nil, nil)
+	req.Header.Set("Authorization", "Bot "+b.Token.Value())
+	return request(b.httpClient(), b.apiURL(), req, unmarshalTo)
+}
+
+// request sends the passed request using the passed client without adding any authentication & unmarshals the response into
+// unmarshalTo if unmarshalTo is not nil. BaseURL is the base URL the request's URL was built from, see makeRequest.
+//
+// It returns the same errors as Bot.Request.
+func request(client *http.Client, baseURL string, req *http.Request, unmarshalTo any) (*util.Response, error) {
+	resp, err := makeRequest(req, client, baseURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return nil, fmt.Errorf("error making request: %w", scrubURLError(err, req.URL, baseURL))
+	}
+	if resp.Status == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
 	}
+	return readResponse(resp, unmarshalTo)
+}
+
+// readResponse returns a DiscordError if the passed response isn't a 2xx response & has an error code in its body, an
+// UnexpectedResponseError if it has none, & otherwise unmarshals the response into unmarshalTo if unmarshalTo is not nil.
+func readResponse(resp *util.Response, unmarshalTo any) (*util.Response, error) {
 	if resp.Status < 200 || resp.Status > 299 {
-		if resp.Status == http.StatusUnauthorized {
-			return nil, ErrUnauthorized
-		}
 		var discordErrorResp struct {
-			Message string `json:"mesage"`
+			Message string `json:"message"`
 			Code    *int   `json:"code"`
 		}
-		err = json.Unmarshal(resp.Body, &discordErrorResp)
+		err := json.Unmarshal(resp.Body, &discordErrorResp)
 		if err != nil || discordErrorResp.Code == nil {
 			return nil, &UnexpectedResponseError{resp}
 		}
@@ -114,18 +322,109 @@ func (b *Bot) Request(req *http.Request, unmarshalTo any) (*util.Response, error
 			message:  discordErrorResp.Message,
 		}
 	}
+	if unmarshalTo != nil && len(resp.Body) > 0 {
+		err := json.Unmarshal(resp.Body, unmarshalTo)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshaling json: %w", err)
+		}
+	}
 	return resp, nil
 }
 
+// retriesKey is the context key holding the number of times a request was resent, read by HookTransport.
+type retriesKey struct{}
+
+// baseURLKey is the context key holding the base URL a request's URL was built from, read by HookTransport to report its route.
+type baseURLKey struct{}
+
+// makeRequest sends the passed request using the passed client like util.MakeRequest, resending it up to MaxRequestRetries times while
+// it's rate limited & Discord asks to wait no longer than MaxRetryWait. Requests with a body that can't be read again aren't resent.
+//
+// BaseURL is the base URL the request's URL was built from, e.g. Bot.BaseURL, so hooks report routes without it. "" uses
+// BaseDiscordAPIURL.
+func makeRequest(req *http.Request, client *http.Client, baseURL string, unmarshalTo any) (*util.Response, error) {
+	req = req.WithContext(context.WithValue(req.Context(), baseURLKey{}, baseURL))
+	attempt := req
+	for retries := 0; ; retries++ {
+		resp, err := util.MakeRequest(attempt, client, nil)
+		if err != nil {
+			return nil, err
+		}
+		wait, ok := retryAfter(resp)
+		if !ok || retries == MaxRequestRetries || wait > MaxRetryWait || (req.Body != nil && req.GetBody == nil) {
+			if unmarshalTo != nil {
+				err = json.Unmarshal(resp.Body, unmarshalTo)
+				if err != nil {
+					return nil, fmt.Errorf("error unmarshaling json: %w", err)
+				}
+			}
+			return resp, nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, fmt.Errorf("error waiting to retry: %w", req.Context().Err())
+		case <-timer.C:
+		}
+		attempt = req.WithContext(context.WithValue(req.Context(), retriesKey{}, retries+1))
+		if req.Body != nil {
+			attempt.Body, err = req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("error reading body: %w", err)
+			}
+		}
+	}
+}
+
+// retryAfter returns how long to wait before resending a request that received the passed response, & false if the response isn't a
+// 429 response.
+func retryAfter(resp *util.Response) (time.Duration, bool) {
+	if resp.Status != http.StatusTooManyRequests {
+		return 0, false
+	}
+	seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	if err != nil {
+		var body struct {
+			RetryAfter float64 `json:"retry_after"`
+		}
+		if json.Unmarshal(resp.Body, &body) != nil {
+			return 0, false
+		}
+		seconds = body.RetryAfter
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
 // FetchApplication fetches the bot's application object.
 //
-// unmarshalTo should be a pointer or nil.
+// If the bot has a Cache, cached application info is returned without a request.
 //
 // Possible Errors:
 //   - ErrUnauthorized: Returned if the bot's token is invalid.
 //   - UnexpectedResponseError: Returned if an unexpected response was received.
 func (b *Bot) FetchApplicationInfo() (*ApplicationInfo, error) {
-	req, err := http.NewRequest(http.MethodGet, BaseDiscordAPIURL+"/oauth2/applications/@me", nil)
+	if b.Cache != nil {
+		var application ApplicationInfo
+		if b.Cache.Get(ApplicationCacheKey(b.Token.Value()), &application) {
+			return &application, nil
+		}
+	}
+	application, err := b.fetchApplicationInfo()
+	if err != nil {
+		return nil, err
+	}
+	if b.Cache != nil {
+		b.Cache.Set(ApplicationCacheKey(b.Token.Value()), *application)
+	}
+	return application, nil
+}
+
+// fetchApplicationInfo fetches the bot's application object without consulting its cache.
+//
+// It returns the same errors as FetchApplicationInfo.
+func (b *Bot) fetchApplicationInfo() (*ApplicationInfo, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/oauth2/applications/@me", nil)
 	if err != nil {
 		return nil, fmt.Errorf("error forming request: %w", err)
 	}
@@ -0,0 +1,255 @@
+package discordapp
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache stores guild, member & application data that Bot's Fetch methods return instead of making a request. Unlike a State, a Cache
+// can be backed by a store shared between several processes, see NewStoreCache. Implementations must be safe for concurrent use.
+//
+// Get stores the value cached under key in value, which must be a pointer, & reports whether a value was found. Set caches value under
+// key until the cache's TTL passes & Delete removes it. Stats returns the number of hits & misses of Get.
+type Cache interface {
+	Get(key string, value any) bool
+	Set(key string, value any)
+	Delete(key string)
+	Stats() CacheStats
+}
+
+// CacheStats holds the hit & miss counts of a cache. Errors counts failed store operations, which are treated as misses.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Errors uint64
+}
+
+// HitRatio returns the fraction of lookups that were hits, or 0 if there were no lookups.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// invalidateGuild removes the guild with the passed guild ID from the bot's Cache. It's called after the guild's roles, channels or emojis
+// change, as the cached guild holds them.
+func (b *Bot) invalidateGuild(guildID Snowflake) {
+	if b.Cache != nil && guildID != 0 {
+		b.Cache.Delete(GuildCacheKey(guildID))
+	}
+}
+
+// GuildCacheKey returns the key Bot caches the guild with the passed guild ID under.
+func GuildCacheKey(guildID Snowflake) string {
+	return "guild:" + guildID.String()
+}
+
+// MemberCacheKey returns the key Bot caches the member with the passed user ID in the guild with the passed guild ID under.
+func MemberCacheKey(guildID Snowflake, userID Snowflake) string {
+	return "member:" + guildID.String() + ":" + userID.String()
+}
+
+// ApplicationCacheKey returns the key Bot caches the application info of the bot with the passed token under. The key holds a hash of
+// the token rather than the token itself.
+func ApplicationCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "application:" + hex.EncodeToString(sum[:8])
+}
+
+type cacheCounters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+}
+
+func (c *cacheCounters) stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load(), Errors: c.errors.Load()}
+}
+
+// LRUCache is an in-memory Cache that evicts the least recently used entry once it holds Size entries.
+//
+// Values are stored as passed to Set rather than serialised, so values returned by Get share slices with the cache & must not be
+// modified.
+type LRUCache struct {
+	mu       sync.Mutex
+	size     int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List
+	counters cacheCounters
+}
+
+type lruEntry struct {
+	key     string
+	value   reflect.Value
+	expires time.Time
+}
+
+// NewLRUCache creates & returns a pointer to an LRU cache holding up to size entries, each kept for ttl. A size of 0 means no limit &
+// a ttl of 0 means entries don't expire.
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{size: size, ttl: ttl, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// Get stores the entry with the passed key in value if it hasn't expired & is assignable to value.
+func (c *LRUCache) Get(key string, value any) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.counters.misses.Add(1)
+		return false
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		c.counters.misses.Add(1)
+		return false
+	}
+	target := reflect.ValueOf(value)
+	if target.Kind() != reflect.Pointer || target.IsNil() || !entry.value.Type().AssignableTo(target.Elem().Type()) {
+		c.counters.misses.Add(1)
+		return false
+	}
+	target.Elem().Set(entry.value)
+	c.order.MoveToFront(element)
+	c.counters.hits.Add(1)
+	return true
+}
+
+// Set caches value under key, evicting the least recently used entry if the cache is full. Pointers are dereferenced before storing.
+func (c *LRUCache) Set(key string, value any) {
+	stored := reflect.Indirect(reflect.ValueOf(value))
+	if !stored.IsValid() {
+		return
+	}
+	entry := &lruEntry{key: key, value: stored}
+	if c.ttl > 0 {
+		entry.expires = time.Now().Add(c.ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the entry with the passed key.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries that haven't been looked up since expiring.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the cache's hit & miss counts.
+func (c *LRUCache) Stats() CacheStats {
+	return c.counters.stats()
+}
+
+// CacheStore is a key-value store with expiring entries, such as Redis, that a StoreCache is backed by.
+//
+// Get must return ErrCacheMiss if there is no entry with the passed key. A ttl of 0 passed to Set means the entry doesn't expire.
+type CacheStore interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Delete(key string) error
+}
+
+// StoreCache is a Cache backed by a CacheStore. Values are serialised as JSON using their existing json tags, so several bots can share
+// a store.
+//
+// Prefix is prepended to every key & OnError, if set, is called with the key & error of every failed store operation or
+// serialisation. Failed lookups are treated as misses.
+type StoreCache struct {
+	Store    CacheStore
+	Prefix   string
+	TTL      time.Duration
+	OnError  func(key string, err error)
+	counters cacheCounters
+}
+
+// NewStoreCache creates & returns a pointer to a cache backed by the passed store, prefixing its keys with prefix & keeping each entry
+// for ttl.
+func NewStoreCache(store CacheStore, prefix string, ttl time.Duration) *StoreCache {
+	return &StoreCache{Store: store, Prefix: prefix, TTL: ttl}
+}
+
+// Get unmarshals the entry with the passed key into value.
+func (c *StoreCache) Get(key string, value any) bool {
+	data, err := c.Store.Get(c.Prefix + key)
+	if err != nil {
+		c.counters.misses.Add(1)
+		if !errors.Is(err, ErrCacheMiss) {
+			c.fail(key, err)
+		}
+		return false
+	}
+	err = json.Unmarshal(data, value)
+	if err != nil {
+		c.counters.misses.Add(1)
+		c.fail(key, err)
+		return false
+	}
+	c.counters.hits.Add(1)
+	return true
+}
+
+// Set marshals value & stores it under key for the cache's TTL.
+func (c *StoreCache) Set(key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.fail(key, err)
+		return
+	}
+	err = c.Store.Set(c.Prefix+key, data, c.TTL)
+	if err != nil {
+		c.fail(key, err)
+	}
+}
+
+// Delete removes the entry with the passed key from the store.
+func (c *StoreCache) Delete(key string) {
+	err := c.Store.Delete(c.Prefix + key)
+	if err != nil && !errors.Is(err, ErrCacheMiss) {
+		c.fail(key, err)
+	}
+}
+
+// Stats returns the cache's hit & miss counts & the number of failed store operations.
+func (c *StoreCache) Stats() CacheStats {
+	return c.counters.stats()
+}
+
+func (c *StoreCache) fail(key string, err error) {
+	c.counters.errors.Add(1)
+	if c.OnError != nil {
+		c.OnError(key, err)
+	}
+}
@@ -0,0 +1,224 @@
+package discordapp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BaseDiscordCDNURL is the base URL of Discord's CDN, which serves images such as avatars & icons.
+const BaseDiscordCDNURL = "https://cdn.discordapp.com"
+
+// Image formats
+//
+// Image URL methods take a format, one of the ImageFormat constants, & a size, a power of two between 16 & 4096.
+// An empty format selects GIF for animated images & PNG otherwise. A size of 0 lets Discord pick the image's default size.
+const (
+	ImageFormatPNG  = "png"
+	ImageFormatJPEG = "jpg"
+	ImageFormatWebP = "webp"
+	ImageFormatGIF  = "gif"
+)
+
+// cdnURL returns the URL of the image with the passed hash at the passed path, e.g. "/icons/{guild.id}".
+func cdnURL(path string, hash string, format string, size int) (string, error) {
+	animated := strings.HasPrefix(hash, "a_")
+	return cdnImageURL(path+"/"+hash, animated, format, size)
+}
+
+func cdnImageURL(path string, animated bool, format string, size int) (string, error) {
+	switch format {
+	case "":
+		format = ImageFormatPNG
+		if animated {
+			format = ImageFormatGIF
+		}
+	case ImageFormatPNG, ImageFormatJPEG, ImageFormatWebP:
+	case ImageFormatGIF:
+		if !animated {
+			return "", fmt.Errorf("%w: gif is only available for animated images", ErrInvalidImageFormat)
+		}
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidImageFormat, format)
+	}
+	link := BaseDiscordCDNURL + path + "." + format
+	if size != 0 {
+		if size < 16 || size > 4096 || size&(size-1) != 0 {
+			return "", fmt.Errorf("%w: %d", ErrInvalidImageSize, size)
+		}
+		link += "?size=" + strconv.Itoa(size)
+	}
+	return link, nil
+}
+
+// DefaultAvatarURL returns the URL of the default avatar of the user with the passed ID & discriminator.
+//
+// Users on the new username system have the discriminator "0" & their default avatar depends on their ID.
+func DefaultAvatarURL(userID Snowflake, discriminator string) string {
+	index := uint64(userID>>22) % 6
+	if discriminator != "" && discriminator != "0" {
+		legacy, err := strconv.Atoi(discriminator)
+		if err == nil {
+			index = uint64(legacy % 5)
+		}
+	}
+	return BaseDiscordCDNURL + "/embed/avatars/" + strconv.FormatUint(index, 10) + ".png"
+}
+
+// userAvatarURL returns the URL of a user's avatar, falling back to their default avatar.
+func userAvatarURL(userID Snowflake, discriminator string, avatar string, format string, size int) (string, error) {
+	if avatar == "" {
+		return DefaultAvatarURL(userID, discriminator), nil
+	}
+	return cdnURL("/avatars/"+userID.String(), avatar, format, size)
+}
+
+// AvatarURL returns the URL of the user's avatar, or of their default avatar if they have none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static avatar.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (u *MemberUser) AvatarURL(format string, size int) (string, error) {
+	return userAvatarURL(u.ID, u.Discriminator, u.Avatar, format, size)
+}
+
+// BannerURL returns the URL of the user's banner or "" if they have none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static banner.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (u *MemberUser) BannerURL(format string, size int) (string, error) {
+	if u.Banner == "" {
+		return "", nil
+	}
+	return cdnURL("/banners/"+u.ID.String(), u.Banner, format, size)
+}
+
+// AvatarURL returns the URL of the member's avatar in the guild with the passed guild ID, falling back to their user avatar.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static avatar.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (m *Member) AvatarURL(guildID Snowflake, format string, size int) (string, error) {
+	if m.Avatar == "" {
+		return m.User.AvatarURL(format, size)
+	}
+	return cdnURL("/guilds/"+guildID.String()+"/users/"+m.User.ID.String()+"/avatars", m.Avatar, format, size)
+}
+
+// AvatarURL returns the URL of the user's avatar, or of their default avatar if they have none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static avatar.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (u *AuthorizedUser) AvatarURL(format string, size int) (string, error) {
+	return userAvatarURL(u.ID, u.Discriminator, u.Avatar, format, size)
+}
+
+// BannerURL returns the URL of the user's banner or "" if they have none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static banner.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (u *AuthorizedUser) BannerURL(format string, size int) (string, error) {
+	if u.Banner == "" {
+		return "", nil
+	}
+	return cdnURL("/banners/"+u.ID.String(), u.Banner, format, size)
+}
+
+// IconURL returns the URL of the guild's icon or "" if it has none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static icon.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (g *Guild) IconURL(format string, size int) (string, error) {
+	if g.Icon == "" {
+		return "", nil
+	}
+	return cdnURL("/icons/"+g.ID.String(), g.Icon, format, size)
+}
+
+// BannerURL returns the URL of the guild's banner or "" if it has none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static banner.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (g *Guild) BannerURL(format string, size int) (string, error) {
+	if g.Banner == "" {
+		return "", nil
+	}
+	return cdnURL("/banners/"+g.ID.String(), g.Banner, format, size)
+}
+
+// SplashURL returns the URL of the guild's invite splash or "" if it has none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (g *Guild) SplashURL(format string, size int) (string, error) {
+	if g.Splash == "" {
+		return "", nil
+	}
+	return cdnURL("/splashes/"+g.ID.String(), g.Splash, format, size)
+}
+
+// DiscoverySplashURL returns the URL of the guild's discovery splash or "" if it has none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (g *Guild) DiscoverySplashURL(format string, size int) (string, error) {
+	if g.DiscoverySplash == nil || *g.DiscoverySplash == "" {
+		return "", nil
+	}
+	return cdnURL("/discovery-splashes/"+g.ID.String(), *g.DiscoverySplash, format, size)
+}
+
+// IconURL returns the URL of the role's icon or "" if it has none. Roles with a unicode emoji as icon have no icon image.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (r *Role) IconURL(format string, size int) (string, error) {
+	if r.Icon == nil || *r.Icon == "" {
+		return "", nil
+	}
+	return cdnURL("/role-icons/"+r.ID.String(), *r.Icon, format, size)
+}
+
+// URL returns the URL of the emoji's image. Standard unicode emojis have no image & return "".
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif is requested for a static emoji.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (e *Emoji) URL(format string, size int) (string, error) {
+	if e.ID == 0 {
+		return "", nil
+	}
+	return cdnImageURL("/emojis/"+e.ID.String(), e.Animated, format, size)
+}
+
+// IconURL returns the URL of the application's icon or "" if it has none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (a *ApplicationInfo) IconURL(format string, size int) (string, error) {
+	if a.Icon == "" {
+		return "", nil
+	}
+	return cdnURL("/app-icons/"+a.ID.String(), a.Icon, format, size)
+}
+
+// CoverImageURL returns the URL of the application's rich presence invite cover image or "" if it has none.
+//
+// Possible Errors:
+//   - ErrInvalidImageFormat: Returned if the format is unknown or gif.
+//   - ErrInvalidImageSize: Returned if the size is not a power of two between 16 & 4096.
+func (a *ApplicationInfo) CoverImageURL(format string, size int) (string, error) {
+	if a.CoverImage == "" {
+		return "", nil
+	}
+	return cdnURL("/app-icons/"+a.ID.String(), a.CoverImage, format, size)
+}
@@ -0,0 +1,485 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Channel types
+const (
+	ChannelTypeGuildText          = 0
+	ChannelTypeDM                 = 1
+	ChannelTypeGuildVoice         = 2
+	ChannelTypeGroupDM            = 3
+	ChannelTypeGuildCategory      = 4
+	ChannelTypeGuildAnnouncement  = 5
+	ChannelTypeAnnouncementThread = 10
+	ChannelTypePublicThread       = 11
+	ChannelTypePrivateThread      = 12
+	ChannelTypeGuildStageVoice    = 13
+	ChannelTypeGuildDirectory     = 14
+	ChannelTypeGuildForum         = 15
+	ChannelTypeGuildMedia         = 16
+)
+
+// Permission overwrite types
+const (
+	OverwriteTypeRole   = 0
+	OverwriteTypeMember = 1
+)
+
+// Channel represents a channel object returned by Discord's API.
+type Channel struct {
+	ID                   Snowflake             `json:"id"`
+	Type                 int                   `json:"type"`
+	GuildID              Snowflake             `json:"guild_id"`
+	Position             int                   `json:"position"`
+	PermissionOverwrites []PermissionOverwrite `json:"permission_overwrites"`
+	Name                 string                `json:"name"`
+	Topic                *string               `json:"topic"`
+	NSFW                 bool                  `json:"nsfw"`
+	LastMessageID        *Snowflake            `json:"last_message_id"`
+	Bitrate              int                   `json:"bitrate"`
+	UserLimit            int                   `json:"user_limit"`
+	RateLimitPerUser     int                   `json:"rate_limit_per_user"`
+	ParentID             *Snowflake            `json:"parent_id"`
+	RTCRegion            *string               `json:"rtc_region"`
+	VideoQualityMode     int                   `json:"video_quality_mode"`
+	Permissions          *Permissions          `json:"permissions"`
+	Flags                int                   `json:"flags"`
+
+	// Thread fields
+	OwnerID          Snowflake       `json:"owner_id"`
+	MessageCount     int             `json:"message_count"`
+	MemberCount      int             `json:"member_count"`
+	TotalMessageSent int             `json:"total_message_sent"`
+	ThreadMetadata   *ThreadMetadata `json:"thread_metadata"`
+	Member           *ThreadMember   `json:"member"`
+	AppliedTags      []Snowflake     `json:"applied_tags"`
+
+	// Forum fields
+	AvailableTags                 []ForumTag       `json:"available_tags"`
+	DefaultReactionEmoji          *DefaultReaction `json:"default_reaction_emoji"`
+	DefaultThreadRateLimitPerUser int              `json:"default_thread_rate_limit_per_user"`
+	DefaultSortOrder              *int             `json:"default_sort_order"`
+	DefaultForumLayout            int              `json:"default_forum_layout"`
+	DefaultAutoArchiveDuration    int              `json:"default_auto_archive_duration"`
+}
+
+// PermissionOverwrite represents a role or member permission overwrite on a channel.
+//
+// Type is either OverwriteTypeRole or OverwriteTypeMember. ID is the ID of the role or member.
+type PermissionOverwrite struct {
+	ID    Snowflake   `json:"id"`
+	Type  int         `json:"type"`
+	Allow Permissions `json:"allow"`
+	Deny  Permissions `json:"deny"`
+}
+
+// CreateGuildChannelParams represents the options used when creating a guild channel.
+//
+// Only Name is required. Type defaults to ChannelTypeGuildText.
+type CreateGuildChannelParams struct {
+	Name                       string                `json:"name"`
+	Type                       int                   `json:"type"`
+	Topic                      string                `json:"topic,omitempty"`
+	Bitrate                    int                   `json:"bitrate,omitempty"`
+	UserLimit                  int                   `json:"user_limit,omitempty"`
+	RateLimitPerUser           int                   `json:"rate_limit_per_user,omitempty"`
+	Position                   *int                  `json:"position,omitempty"`
+	PermissionOverwrites       []PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	ParentID                   Snowflake             `json:"parent_id,omitempty"`
+	NSFW                       bool                  `json:"nsfw,omitempty"`
+	RTCRegion                  string                `json:"rtc_region,omitempty"`
+	VideoQualityMode           int                   `json:"video_quality_mode,omitempty"`
+	DefaultAutoArchiveDuration int                   `json:"default_auto_archive_duration,omitempty"`
+
+	// Forum fields
+	AvailableTags                 []ForumTag       `json:"available_tags,omitempty"`
+	DefaultReactionEmoji          *DefaultReaction `json:"default_reaction_emoji,omitempty"`
+	DefaultThreadRateLimitPerUser int              `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultSortOrder              *int             `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            int              `json:"default_forum_layout,omitempty"`
+}
+
+// ModifyChannelParams represents the fields that can be changed on a guild channel or thread. Nil fields are left unchanged.
+//
+// The thread fields only apply to threads & the forum fields only apply to forum & media channels. ParentID can be set to a pointer to 0
+// to move the channel out of its category.
+type ModifyChannelParams struct {
+	Name                       *string                `json:"name,omitempty"`
+	Type                       *int                   `json:"type,omitempty"`
+	Position                   *int                   `json:"position,omitempty"`
+	Topic                      *string                `json:"topic,omitempty"`
+	NSFW                       *bool                  `json:"nsfw,omitempty"`
+	RateLimitPerUser           *int                   `json:"rate_limit_per_user,omitempty"`
+	Bitrate                    *int                   `json:"bitrate,omitempty"`
+	UserLimit                  *int                   `json:"user_limit,omitempty"`
+	PermissionOverwrites       *[]PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	ParentID                   *Snowflake             `json:"parent_id,omitempty"`
+	RTCRegion                  *string                `json:"rtc_region,omitempty"`
+	VideoQualityMode           *int                   `json:"video_quality_mode,omitempty"`
+	DefaultAutoArchiveDuration *int                   `json:"default_auto_archive_duration,omitempty"`
+	Flags                      *int                   `json:"flags,omitempty"`
+
+	// Forum fields
+	AvailableTags                 *[]ForumTag      `json:"available_tags,omitempty"`
+	DefaultReactionEmoji          *DefaultReaction `json:"default_reaction_emoji,omitempty"`
+	DefaultThreadRateLimitPerUser *int             `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultSortOrder              *int             `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            *int             `json:"default_forum_layout,omitempty"`
+
+	// Thread fields
+	Archived            *bool        `json:"archived,omitempty"`
+	AutoArchiveDuration *int         `json:"auto_archive_duration,omitempty"`
+	Locked              *bool        `json:"locked,omitempty"`
+	Invitable           *bool        `json:"invitable,omitempty"`
+	AppliedTags         *[]Snowflake `json:"applied_tags,omitempty"`
+}
+
+// MarshalJSON encodes the params as expected by Discord's API, encoding a ParentID of 0 as null to move the channel out of its category.
+func (p ModifyChannelParams) MarshalJSON() ([]byte, error) {
+	type params ModifyChannelParams
+	return json.Marshal(struct {
+		params
+		ParentID *clearableSnowflake `json:"parent_id,omitempty"`
+	}{params(p), (*clearableSnowflake)(p.ParentID)})
+}
+
+// ChannelPosition represents the new position of a channel when modifying guild channel positions.
+//
+// LockPermissions syncs the channel's permission overwrites with its new parent. ParentID can be nil to leave the parent unchanged.
+type ChannelPosition struct {
+	ID              Snowflake  `json:"id"`
+	Position        *int       `json:"position,omitempty"`
+	LockPermissions *bool      `json:"lock_permissions,omitempty"`
+	ParentID        *Snowflake `json:"parent_id,omitempty"`
+}
+
+// FetchChannel fetches the channel with the passed channel ID.
+//
+// If the bot has a State, a channel cached within the state's MaxAge is returned without a request.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+func (b *Bot) FetchChannel(channelID Snowflake) (*Channel, error) {
+	if b.State != nil {
+		if channel, ok := b.State.channel(channelID, b.State.config.MaxAge); ok {
+			return channel, nil
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/channels/"+channelID.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var channel Channel
+	resp, err := b.Request(req, &channel)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		b.State.SetChannel(channel)
+	}
+	return &channel, nil
+}
+
+// ListGuildChannels lists the channels of the guild with the passed guild ID. Threads are not included.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) ListGuildChannels(guildID Snowflake) ([]Channel, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/channels", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var channels []Channel
+	resp, err := b.Request(req, &channels)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		for _, channel := range channels {
+			b.State.SetChannel(channel)
+		}
+	}
+	return channels, nil
+}
+
+// CreateGuildChannel creates a channel in the guild with the passed guild ID & returns the created channel.
+//
+// Text, voice, category, announcement, stage & forum channels can be created by setting the params' Type.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage channels.
+func (b *Bot) CreateGuildChannel(guildID Snowflake, params CreateGuildChannelParams) (*Channel, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/channels", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var channel Channel
+	resp, err := b.Request(req, &channel)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusCreated && resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		b.State.SetChannel(channel)
+	}
+	b.invalidateGuild(guildID)
+	return &channel, nil
+}
+
+// ModifyChannel modifies the guild channel or thread with the passed channel ID & returns the updated channel.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the channel.
+func (b *Bot) ModifyChannel(channelID Snowflake, params ModifyChannelParams) (*Channel, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/channels/"+channelID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var channel Channel
+	resp, err := b.Request(req, &channel)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		b.State.SetChannel(channel)
+	}
+	b.invalidateGuild(channel.GuildID)
+	return &channel, nil
+}
+
+// DeleteChannel deletes the channel with the passed channel ID & returns the deleted channel.
+//
+// Deleting a category does not delete its child channels.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the channel.
+func (b *Bot) DeleteChannel(channelID Snowflake) (*Channel, error) {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/channels/"+channelID.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var channel Channel
+	resp, err := b.Request(req, &channel)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		b.State.RemoveChannel(channelID)
+	}
+	b.invalidateGuild(channel.GuildID)
+	return &channel, nil
+}
+
+// ModifyGuildChannelPositions modifies the positions & parents of a set of channels in the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage channels.
+func (b *Bot) ModifyGuildChannelPositions(guildID Snowflake, positions []ChannelPosition) error {
+	body, err := json.Marshal(positions)
+	if err != nil {
+		return fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/channels", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		for _, position := range positions {
+			b.State.RemoveChannel(position.ID)
+		}
+	}
+	b.invalidateGuild(guildID)
+	return nil
+}
+
+// EditChannelPermissions creates or replaces the permission overwrite of a role or member on the channel with the passed channel ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage roles in the channel.
+func (b *Bot) EditChannelPermissions(channelID Snowflake, overwrite PermissionOverwrite) error {
+	payload := struct {
+		Allow Permissions `json:"allow"`
+		Deny  Permissions `json:"deny"`
+		Type  int         `json:"type"`
+	}{overwrite.Allow, overwrite.Deny, overwrite.Type}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, b.apiURL()+"/channels/"+channelID.String()+"/permissions/"+overwrite.ID.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return ErrChannelNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	b.channelOverwritesChanged(channelID)
+	return nil
+}
+
+// DeleteChannelPermission deletes the permission overwrite of the role or member with the passed overwrite ID on the channel with the passed channel ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage roles in the channel.
+func (b *Bot) DeleteChannelPermission(channelID Snowflake, overwriteID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/channels/"+channelID.String()+"/permissions/"+overwriteID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return ErrChannelNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	b.channelOverwritesChanged(channelID)
+	return nil
+}
+
+// channelOverwritesChanged drops the channel with the passed channel ID from the bot's State after its permission overwrites change, &
+// its guild from the bot's Cache if the State knows which guild it's in.
+func (b *Bot) channelOverwritesChanged(channelID Snowflake) {
+	if b.State == nil {
+		return
+	}
+	if channel, ok := b.State.Channel(channelID); ok {
+		b.invalidateGuild(channel.GuildID)
+	}
+	b.State.RemoveChannel(channelID)
+}
@@ -0,0 +1,223 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// Application command permission types
+const (
+	ApplicationCommandPermissionTypeRole    = 1
+	ApplicationCommandPermissionTypeUser    = 2
+	ApplicationCommandPermissionTypeChannel = 3
+)
+
+// MaxCommandPermissions is the maximum number of permission entries of a command in a guild.
+const MaxCommandPermissions = 100
+
+// GuildApplicationCommandPermissions represents the permissions of a command in a guild returned by Discord's API.
+//
+// ID is the command's ID, or the application's ID for permissions that apply to all of the application's commands.
+type GuildApplicationCommandPermissions struct {
+	ID            Snowflake                      `json:"id"`
+	ApplicationID Snowflake                      `json:"application_id"`
+	GuildID       Snowflake                      `json:"guild_id"`
+	Permissions   []ApplicationCommandPermission `json:"permissions"`
+}
+
+// ApplicationCommandPermission allows or denies a role, user or channel the use of a command.
+//
+// The @everyone role & all channels are referenced by the sentinel IDs returned by EveryoneRoleID & AllChannelsID.
+type ApplicationCommandPermission struct {
+	ID         Snowflake `json:"id"`
+	Type       int       `json:"type"`
+	Permission bool      `json:"permission"`
+}
+
+// EveryoneRoleID returns the ID of the @everyone role of the guild with the passed guild ID, which is the guild's ID.
+func EveryoneRoleID(guildID Snowflake) Snowflake {
+	return guildID
+}
+
+// AllChannelsID returns the sentinel ID that stands for every channel of the guild with the passed guild ID, which is the guild's ID minus 1.
+func AllChannelsID(guildID Snowflake) Snowflake {
+	return guildID - 1
+}
+
+// RoleCommandPermission returns a permission allowing or denying the role with the passed role ID the use of a command.
+func RoleCommandPermission(roleID Snowflake, allowed bool) ApplicationCommandPermission {
+	return ApplicationCommandPermission{ID: roleID, Type: ApplicationCommandPermissionTypeRole, Permission: allowed}
+}
+
+// UserCommandPermission returns a permission allowing or denying the user with the passed user ID the use of a command.
+func UserCommandPermission(userID Snowflake, allowed bool) ApplicationCommandPermission {
+	return ApplicationCommandPermission{ID: userID, Type: ApplicationCommandPermissionTypeUser, Permission: allowed}
+}
+
+// ChannelCommandPermission returns a permission allowing or denying the use of a command in the channel with the passed channel ID.
+func ChannelCommandPermission(channelID Snowflake, allowed bool) ApplicationCommandPermission {
+	return ApplicationCommandPermission{ID: channelID, Type: ApplicationCommandPermissionTypeChannel, Permission: allowed}
+}
+
+// EveryoneCommandPermission returns a permission allowing or denying everyone in the guild with the passed guild ID the use of a command.
+func EveryoneCommandPermission(guildID Snowflake, allowed bool) ApplicationCommandPermission {
+	return RoleCommandPermission(EveryoneRoleID(guildID), allowed)
+}
+
+// AllChannelsCommandPermission returns a permission allowing or denying the use of a command in every channel of the guild with the passed guild ID.
+func AllChannelsCommandPermission(guildID Snowflake, allowed bool) ApplicationCommandPermission {
+	return ChannelCommandPermission(AllChannelsID(guildID), allowed)
+}
+
+// commandPermissionsURL returns the URL of the command permissions of the application with the passed ID in the guild with the passed guild ID.
+// CommandID can be 0 for the permissions of every command.
+func (b *Bot) commandPermissionsURL(applicationID Snowflake, guildID Snowflake, commandID Snowflake) string {
+	link := b.apiURL() + "/applications/" + applicationID.String() + "/guilds/" + guildID.String() + "/commands"
+	if commandID != 0 {
+		link += "/" + commandID.String()
+	}
+	return link + "/permissions"
+}
+
+// commandPermissionsError maps the errors of command permission endpoints.
+func commandPermissionsError(err error) error {
+	var discordErr *DiscordError
+	if errors.As(err, &discordErr) {
+		if discordErr.code == 10004 {
+			return ErrGuildNotFound
+		}
+		if discordErr.code == 10063 || discordErr.code == 10066 {
+			return ErrCommandNotFound
+		}
+		if discordErr.code == 50001 || discordErr.code == 50013 {
+			return ErrMissingPermissions
+		}
+		if discordErr.code == 50025 {
+			return ErrInvalidAccessToken
+		}
+	}
+	return fmt.Errorf("error making request: %w", err)
+}
+
+// FetchGuildCommandPermissions fetches the permissions of the bot's commands in the guild with the passed guild ID.
+// Commands without permissions set in the guild are left out.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) FetchGuildCommandPermissions(guildID Snowflake) ([]GuildApplicationCommandPermissions, error) {
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, b.commandPermissionsURL(applicationID, guildID, 0), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var permissions []GuildApplicationCommandPermissions
+	resp, err := b.Request(req, &permissions)
+	if err != nil {
+		return nil, commandPermissionsError(err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return permissions, nil
+}
+
+// FetchCommandPermissions fetches the permissions of the command with the passed command ID in the guild with the passed guild ID.
+// The application's ID can be passed as the command ID to fetch the permissions that apply to all of its commands.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrCommandNotFound: Returned if the command does not exist or has no permissions set in the guild.
+func (b *Bot) FetchCommandPermissions(guildID Snowflake, commandID Snowflake) (*GuildApplicationCommandPermissions, error) {
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, b.commandPermissionsURL(applicationID, guildID, commandID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var permissions GuildApplicationCommandPermissions
+	resp, err := b.Request(req, &permissions)
+	if err != nil {
+		return nil, commandPermissionsError(err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &permissions, nil
+}
+
+// EditCommandPermissions replaces the permissions of the command with the passed command ID in the guild with the passed guild ID.
+// The application's ID can be passed as the command ID to edit the permissions that apply to all of its commands.
+//
+// Bot tokens can't edit command permissions. The access token must belong to a user who can manage the guild & its roles & must have been
+// granted the ScopeApplicationsCommandsPermissionsUpdate scope, see CreateAuthLink. The token's scopes are checked before the
+// permissions are sent.
+//
+// Possible Errors:
+//   - ErrInvalidAccessToken: Returned if the access token is invalid.
+//   - ErrMissingScope: Returned if the access token wasn't granted the ScopeApplicationsCommandsPermissionsUpdate scope.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrInvalidCommandPermissions: Returned if there are more than 100 permissions.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrCommandNotFound: Returned if the command does not exist.
+//   - ErrMissingPermissions: Returned if the user lacks permissions in the guild.
+func (a *Application) EditCommandPermissions(accessToken string, guildID Snowflake, commandID Snowflake, permissions []ApplicationCommandPermission) (*GuildApplicationCommandPermissions, error) {
+	if len(permissions) > MaxCommandPermissions {
+		return nil, fmt.Errorf("%w: %d permissions, the maximum is %d", ErrInvalidCommandPermissions, len(permissions), MaxCommandPermissions)
+	}
+	if permissions == nil {
+		permissions = []ApplicationCommandPermission{}
+	}
+	applicationID, err := a.Bot.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	authInfo, err := fetchAuthInfo(a.Bot.httpClient(), a.Bot.apiURL(), accessToken)
+	if err != nil {
+		if err == ErrInvalidAccessToken {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error fetching authorization info: %w", err)
+	}
+	if !slices.Contains(authInfo.Scopes, ScopeApplicationsCommandsPermissionsUpdate) {
+		return nil, fmt.Errorf("%w: %s wasn't granted", ErrMissingScope, ScopeApplicationsCommandsPermissionsUpdate)
+	}
+	body, err := json.Marshal(struct {
+		Permissions []ApplicationCommandPermission `json:"permissions"`
+	}{permissions})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, a.Bot.commandPermissionsURL(applicationID, guildID, commandID), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	var edited GuildApplicationCommandPermissions
+	resp, err := request(a.Bot.httpClient(), a.Bot.apiURL(), req, &edited)
+	if err != nil {
+		if err == ErrUnauthorized {
+			return nil, ErrInvalidAccessToken
+		}
+		return nil, commandPermissionsError(err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &edited, nil
+}
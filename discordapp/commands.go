@@ -0,0 +1,268 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Application command types
+const (
+	ApplicationCommandTypeChatInput = 1
+	ApplicationCommandTypeUser      = 2
+	ApplicationCommandTypeMessage   = 3
+)
+
+// Application command option types
+const (
+	ApplicationCommandOptionTypeSubCommand      = 1
+	ApplicationCommandOptionTypeSubCommandGroup = 2
+	ApplicationCommandOptionTypeString          = 3
+	ApplicationCommandOptionTypeInteger         = 4
+	ApplicationCommandOptionTypeBoolean         = 5
+	ApplicationCommandOptionTypeUser            = 6
+	ApplicationCommandOptionTypeChannel         = 7
+	ApplicationCommandOptionTypeRole            = 8
+	ApplicationCommandOptionTypeMentionable     = 9
+	ApplicationCommandOptionTypeNumber          = 10
+	ApplicationCommandOptionTypeAttachment      = 11
+)
+
+// ApplicationCommand represents an application command object sent to & returned by Discord's API.
+//
+// DefaultMemberPermissions can be nil to let everyone use the command.
+type ApplicationCommand struct {
+	ID                       Snowflake                  `json:"id,omitempty"`
+	Type                     int                        `json:"type,omitempty"`
+	ApplicationID            Snowflake                  `json:"application_id,omitempty"`
+	GuildID                  Snowflake                  `json:"guild_id,omitempty"`
+	Name                     string                     `json:"name"`
+	NameLocalizations        map[string]string          `json:"name_localizations,omitempty"`
+	Description              string                     `json:"description"`
+	DescriptionLocalizations map[string]string          `json:"description_localizations,omitempty"`
+	Options                  []ApplicationCommandOption `json:"options,omitempty"`
+	DefaultMemberPermissions *Permissions               `json:"default_member_permissions,omitempty"`
+	DMPermission             *bool                      `json:"dm_permission,omitempty"`
+	NSFW                     bool                       `json:"nsfw,omitempty"`
+	Version                  Snowflake                  `json:"version,omitempty"`
+}
+
+// ApplicationCommandOption represents an option of an application command, including subcommands & subcommand groups.
+//
+// MinValue & MaxValue apply to integer & number options, MinLength & MaxLength to string options.
+type ApplicationCommandOption struct {
+	Type                     int                              `json:"type"`
+	Name                     string                           `json:"name"`
+	NameLocalizations        map[string]string                `json:"name_localizations,omitempty"`
+	Description              string                           `json:"description"`
+	DescriptionLocalizations map[string]string                `json:"description_localizations,omitempty"`
+	Required                 bool                             `json:"required,omitempty"`
+	Choices                  []ApplicationCommandOptionChoice `json:"choices,omitempty"`
+	Options                  []ApplicationCommandOption       `json:"options,omitempty"`
+	ChannelTypes             []int                            `json:"channel_types,omitempty"`
+	MinValue                 *float64                         `json:"min_value,omitempty"`
+	MaxValue                 *float64                         `json:"max_value,omitempty"`
+	MinLength                *int                             `json:"min_length,omitempty"`
+	MaxLength                *int                             `json:"max_length,omitempty"`
+	Autocomplete             bool                             `json:"autocomplete,omitempty"`
+}
+
+// ApplicationCommandData represents the data of an application command or autocomplete interaction.
+//
+// TargetID is the ID of the user or message a user or message command was used on.
+type ApplicationCommandData struct {
+	ID       Snowflake                                 `json:"id"`
+	Name     string                                    `json:"name"`
+	Type     int                                       `json:"type"`
+	Resolved ResolvedData                              `json:"resolved"`
+	Options  []ApplicationCommandInteractionDataOption `json:"options"`
+	GuildID  Snowflake                                 `json:"guild_id"`
+	TargetID Snowflake                                 `json:"target_id"`
+}
+
+// ApplicationCommandInteractionDataOption represents an option a user filled in when using a command.
+//
+// Value is left raw as its type depends on Type. Focused is true for the option being autocompleted.
+type ApplicationCommandInteractionDataOption struct {
+	Name    string                                    `json:"name"`
+	Type    int                                       `json:"type"`
+	Value   json.RawMessage                           `json:"value"`
+	Options []ApplicationCommandInteractionDataOption `json:"options"`
+	Focused bool                                      `json:"focused"`
+}
+
+// ResolvedData holds the users, members, roles, channels, messages & attachments referenced by an interaction's options or values.
+//
+// Members are partial & don't include their user, which is in Users.
+type ResolvedData struct {
+	Users       map[Snowflake]MemberUser `json:"users"`
+	Members     map[Snowflake]Member     `json:"members"`
+	Roles       map[Snowflake]Role       `json:"roles"`
+	Channels    map[Snowflake]Channel    `json:"channels"`
+	Messages    map[Snowflake]Message    `json:"messages"`
+	Attachments map[Snowflake]Attachment `json:"attachments"`
+}
+
+// ComponentData represents the data of a message component interaction. Values is set for select menus.
+type ComponentData struct {
+	CustomID      string       `json:"custom_id"`
+	ComponentType int          `json:"component_type"`
+	Values        []string     `json:"values"`
+	Resolved      ResolvedData `json:"resolved"`
+}
+
+// ModalSubmitData represents the data of a modal submit interaction.
+type ModalSubmitData struct {
+	CustomID   string                 `json:"custom_id"`
+	Components []ModalSubmitComponent `json:"components"`
+}
+
+// ModalSubmitComponent represents a submitted modal component. Action rows hold the submitted text inputs in Components.
+type ModalSubmitComponent struct {
+	Type       int                    `json:"type"`
+	CustomID   string                 `json:"custom_id"`
+	Value      string                 `json:"value"`
+	Components []ModalSubmitComponent `json:"components"`
+}
+
+// Values returns the values of the submitted text inputs by custom ID.
+func (d *ModalSubmitData) Values() map[string]string {
+	values := map[string]string{}
+	var collect func(components []ModalSubmitComponent)
+	collect = func(components []ModalSubmitComponent) {
+		for _, component := range components {
+			if component.CustomID != "" {
+				values[component.CustomID] = component.Value
+			}
+			collect(component.Components)
+		}
+	}
+	collect(d.Components)
+	return values
+}
+
+// CommandData returns the data of an application command or autocomplete interaction.
+func (i *Interaction) CommandData() (*ApplicationCommandData, error) {
+	if i.Type != InteractionTypeApplicationCommand && i.Type != InteractionTypeApplicationCommandAutocomplete {
+		return nil, fmt.Errorf("interaction of type %d has no command data", i.Type)
+	}
+	var data ApplicationCommandData
+	err := json.Unmarshal(i.Data, &data)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling json: %w", err)
+	}
+	return &data, nil
+}
+
+// ComponentData returns the data of a message component interaction.
+func (i *Interaction) ComponentData() (*ComponentData, error) {
+	if i.Type != InteractionTypeMessageComponent {
+		return nil, fmt.Errorf("interaction of type %d has no component data", i.Type)
+	}
+	var data ComponentData
+	err := json.Unmarshal(i.Data, &data)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling json: %w", err)
+	}
+	return &data, nil
+}
+
+// ModalSubmitData returns the data of a modal submit interaction.
+func (i *Interaction) ModalSubmitData() (*ModalSubmitData, error) {
+	if i.Type != InteractionTypeModalSubmit {
+		return nil, fmt.Errorf("interaction of type %d has no modal submit data", i.Type)
+	}
+	var data ModalSubmitData
+	err := json.Unmarshal(i.Data, &data)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling json: %w", err)
+	}
+	return &data, nil
+}
+
+// Invoker returns the user who triggered the interaction, from Member in guilds & User in DMs.
+func (i *Interaction) Invoker() *MemberUser {
+	if i.Member != nil {
+		return &i.Member.User
+	}
+	return i.User
+}
+
+// commandsURL returns the URL of the bot's global commands, or of its commands in the guild with the passed guild ID if it is not 0.
+func (b *Bot) commandsURL(guildID Snowflake) (string, error) {
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return "", err
+	}
+	if guildID == 0 {
+		return b.apiURL() + "/applications/" + applicationID.String() + "/commands", nil
+	}
+	return b.apiURL() + "/applications/" + applicationID.String() + "/guilds/" + guildID.String() + "/commands", nil
+}
+
+// FetchCommands fetches the bot's commands. GuildID can be 0 to fetch global commands.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot was not authorized with the applications.commands scope in the guild.
+func (b *Bot) FetchCommands(guildID Snowflake) ([]ApplicationCommand, error) {
+	link, err := b.commandsURL(guildID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.commandsRequest(req)
+}
+
+// BulkOverwriteCommands replaces the bot's commands with the passed commands & returns the registered commands. GuildID can be 0 to overwrite global commands.
+//
+// Commands with the same name as an existing command update it, other existing commands are deleted.
+//
+// It returns the same errors as FetchCommands.
+func (b *Bot) BulkOverwriteCommands(guildID Snowflake, commands []ApplicationCommand) ([]ApplicationCommand, error) {
+	if commands == nil {
+		commands = []ApplicationCommand{}
+	}
+	body, err := json.Marshal(commands)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	link, err := b.commandsURL(guildID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPut, link, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.commandsRequest(req)
+}
+
+func (b *Bot) commandsRequest(req *http.Request) ([]ApplicationCommand, error) {
+	var commands []ApplicationCommand
+	resp, err := b.Request(req, &commands)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50001 || discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return commands, nil
+}
@@ -0,0 +1,738 @@
+package discordapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Component types
+const (
+	ComponentTypeActionRow         = 1
+	ComponentTypeButton            = 2
+	ComponentTypeStringSelect      = 3
+	ComponentTypeTextInput         = 4
+	ComponentTypeUserSelect        = 5
+	ComponentTypeRoleSelect        = 6
+	ComponentTypeMentionableSelect = 7
+	ComponentTypeChannelSelect     = 8
+	ComponentTypeSection           = 9
+	ComponentTypeTextDisplay       = 10
+	ComponentTypeThumbnail         = 11
+	ComponentTypeMediaGallery      = 12
+	ComponentTypeFile              = 13
+	ComponentTypeSeparator         = 14
+	ComponentTypeContainer         = 17
+)
+
+// Button styles
+const (
+	ButtonStylePrimary   = 1
+	ButtonStyleSecondary = 2
+	ButtonStyleSuccess   = 3
+	ButtonStyleDanger    = 4
+	ButtonStyleLink      = 5
+	ButtonStylePremium   = 6
+)
+
+// Text input styles
+const (
+	TextInputStyleShort     = 1
+	TextInputStyleParagraph = 2
+)
+
+// Separator spacings
+const (
+	SeparatorSpacingSmall = 1
+	SeparatorSpacingLarge = 2
+)
+
+// Component limits
+const (
+	MaxActionRows             = 5
+	MaxButtonsPerRow          = 5
+	MaxSelectOptions          = 25
+	MaxCustomIDLength         = 100
+	MaxComponentsV2           = 40
+	MaxComponentsV2TextLength = 4000
+)
+
+// Component is a message or modal component: ActionRow, Button, SelectMenu, TextInput, Section, TextDisplay, Thumbnail, MediaGallery,
+// FileComponent, Separator or Container.
+//
+// Section, TextDisplay, Thumbnail, MediaGallery, FileComponent, Separator & Container are layout components, which require
+// MessageFlagIsComponentsV2 & replace the message's content & embeds.
+type Component interface {
+	ComponentType() int
+}
+
+// ComponentEmoji represents the emoji of a button or select option. ID is 0 for unicode emojis.
+type ComponentEmoji struct {
+	ID       Snowflake `json:"id,omitempty"`
+	Name     string    `json:"name,omitempty"`
+	Animated bool      `json:"animated,omitempty"`
+}
+
+// ActionRow holds up to 5 buttons, a single select menu or, in modals, a single text input.
+type ActionRow struct {
+	ID         int         `json:"id,omitempty"`
+	Components []Component `json:"components"`
+}
+
+// Button represents a button. Link buttons need URL, premium buttons SKUID & other styles CustomID.
+type Button struct {
+	ID       int             `json:"id,omitempty"`
+	Style    int             `json:"style"`
+	Label    string          `json:"label,omitempty"`
+	Emoji    *ComponentEmoji `json:"emoji,omitempty"`
+	CustomID string          `json:"custom_id,omitempty"`
+	SKUID    Snowflake       `json:"sku_id,omitempty"`
+	URL      string          `json:"url,omitempty"`
+	Disabled bool            `json:"disabled,omitempty"`
+}
+
+// SelectMenu represents a select menu. MenuType is one of ComponentTypeStringSelect, ComponentTypeUserSelect, ComponentTypeRoleSelect,
+// ComponentTypeMentionableSelect or ComponentTypeChannelSelect.
+//
+// Options is only used by string selects & ChannelTypes by channel selects. MinValues can be nil for Discord's default of 1.
+type SelectMenu struct {
+	MenuType      int                  `json:"-"`
+	ID            int                  `json:"id,omitempty"`
+	CustomID      string               `json:"custom_id"`
+	Options       []SelectOption       `json:"options,omitempty"`
+	ChannelTypes  []int                `json:"channel_types,omitempty"`
+	Placeholder   string               `json:"placeholder,omitempty"`
+	DefaultValues []SelectDefaultValue `json:"default_values,omitempty"`
+	MinValues     *int                 `json:"min_values,omitempty"`
+	MaxValues     int                  `json:"max_values,omitempty"`
+	Disabled      bool                 `json:"disabled,omitempty"`
+}
+
+// SelectOption represents an option of a string select.
+type SelectOption struct {
+	Label       string          `json:"label"`
+	Value       string          `json:"value"`
+	Description string          `json:"description,omitempty"`
+	Emoji       *ComponentEmoji `json:"emoji,omitempty"`
+	Default     bool            `json:"default,omitempty"`
+}
+
+// SelectDefaultValue represents a value selected by default in an auto-populated select. Type is "user", "role" or "channel".
+type SelectDefaultValue struct {
+	ID   Snowflake `json:"id"`
+	Type string    `json:"type"`
+}
+
+// TextInput represents a text input in a modal. Required can be nil for Discord's default of true.
+type TextInput struct {
+	ID          int    `json:"id,omitempty"`
+	CustomID    string `json:"custom_id"`
+	Style       int    `json:"style"`
+	Label       string `json:"label"`
+	MinLength   int    `json:"min_length,omitempty"`
+	MaxLength   int    `json:"max_length,omitempty"`
+	Required    *bool  `json:"required,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Placeholder string `json:"placeholder,omitempty"`
+}
+
+// Section shows 1 to 3 text displays next to an accessory, which is a Button or a Thumbnail.
+type Section struct {
+	ID         int         `json:"id,omitempty"`
+	Components []Component `json:"components"`
+	Accessory  Component   `json:"accessory"`
+}
+
+// TextDisplay shows markdown text.
+type TextDisplay struct {
+	ID      int    `json:"id,omitempty"`
+	Content string `json:"content"`
+}
+
+// UnfurledMediaItem references media by URL, either an external URL or attachment://{filename} for an uploaded file.
+type UnfurledMediaItem struct {
+	URL string `json:"url"`
+}
+
+// Thumbnail shows a small image as the accessory of a section.
+type Thumbnail struct {
+	ID          int               `json:"id,omitempty"`
+	Media       UnfurledMediaItem `json:"media"`
+	Description string            `json:"description,omitempty"`
+	Spoiler     bool              `json:"spoiler,omitempty"`
+}
+
+// MediaGallery shows 1 to 10 images or videos in a grid.
+type MediaGallery struct {
+	ID    int                `json:"id,omitempty"`
+	Items []MediaGalleryItem `json:"items"`
+}
+
+// MediaGalleryItem represents an item of a media gallery.
+type MediaGalleryItem struct {
+	Media       UnfurledMediaItem `json:"media"`
+	Description string            `json:"description,omitempty"`
+	Spoiler     bool              `json:"spoiler,omitempty"`
+}
+
+// FileComponent shows an uploaded file. File's URL must be attachment://{filename}.
+type FileComponent struct {
+	ID      int               `json:"id,omitempty"`
+	File    UnfurledMediaItem `json:"file"`
+	Spoiler bool              `json:"spoiler,omitempty"`
+}
+
+// Separator adds vertical space & an optional divider line. Divider can be nil for Discord's default of true.
+type Separator struct {
+	ID      int   `json:"id,omitempty"`
+	Divider *bool `json:"divider,omitempty"`
+	Spacing int   `json:"spacing,omitempty"`
+}
+
+// Container groups components in a box with an optional accent color, like an embed.
+type Container struct {
+	ID          int         `json:"id,omitempty"`
+	Components  []Component `json:"components"`
+	AccentColor *int        `json:"accent_color,omitempty"`
+	Spoiler     bool        `json:"spoiler,omitempty"`
+}
+
+// Modal represents a modal shown in response to an interaction. Components should be action rows holding a single text input each.
+type Modal struct {
+	CustomID   string      `json:"custom_id"`
+	Title      string      `json:"title"`
+	Components []Component `json:"components"`
+}
+
+func (ActionRow) ComponentType() int     { return ComponentTypeActionRow }
+func (Button) ComponentType() int        { return ComponentTypeButton }
+func (s SelectMenu) ComponentType() int  { return s.MenuType }
+func (TextInput) ComponentType() int     { return ComponentTypeTextInput }
+func (Section) ComponentType() int       { return ComponentTypeSection }
+func (TextDisplay) ComponentType() int   { return ComponentTypeTextDisplay }
+func (Thumbnail) ComponentType() int     { return ComponentTypeThumbnail }
+func (MediaGallery) ComponentType() int  { return ComponentTypeMediaGallery }
+func (FileComponent) ComponentType() int { return ComponentTypeFile }
+func (Separator) ComponentType() int     { return ComponentTypeSeparator }
+func (Container) ComponentType() int     { return ComponentTypeContainer }
+
+// marshalComponent marshals the passed component with its type added.
+func marshalComponent(componentType int, component any) ([]byte, error) {
+	data, err := json.Marshal(component)
+	if err != nil {
+		return nil, err
+	}
+	typeField := `{"type":` + strconv.Itoa(componentType)
+	if len(data) == 2 {
+		return []byte(typeField + "}"), nil
+	}
+	return append([]byte(typeField+","), data[1:]...), nil
+}
+
+func (c ActionRow) MarshalJSON() ([]byte, error) {
+	type actionRow ActionRow
+	return marshalComponent(ComponentTypeActionRow, actionRow(c))
+}
+
+func (c Button) MarshalJSON() ([]byte, error) {
+	type button Button
+	return marshalComponent(ComponentTypeButton, button(c))
+}
+
+func (s SelectMenu) MarshalJSON() ([]byte, error) {
+	type selectMenu SelectMenu
+	return marshalComponent(s.MenuType, selectMenu(s))
+}
+
+func (c TextInput) MarshalJSON() ([]byte, error) {
+	type textInput TextInput
+	return marshalComponent(ComponentTypeTextInput, textInput(c))
+}
+
+func (c Section) MarshalJSON() ([]byte, error) {
+	type section Section
+	return marshalComponent(ComponentTypeSection, section(c))
+}
+
+func (c TextDisplay) MarshalJSON() ([]byte, error) {
+	type textDisplay TextDisplay
+	return marshalComponent(ComponentTypeTextDisplay, textDisplay(c))
+}
+
+func (c Thumbnail) MarshalJSON() ([]byte, error) {
+	type thumbnail Thumbnail
+	return marshalComponent(ComponentTypeThumbnail, thumbnail(c))
+}
+
+func (c MediaGallery) MarshalJSON() ([]byte, error) {
+	type mediaGallery MediaGallery
+	return marshalComponent(ComponentTypeMediaGallery, mediaGallery(c))
+}
+
+func (c FileComponent) MarshalJSON() ([]byte, error) {
+	type fileComponent FileComponent
+	return marshalComponent(ComponentTypeFile, fileComponent(c))
+}
+
+func (c Separator) MarshalJSON() ([]byte, error) {
+	type separator Separator
+	return marshalComponent(ComponentTypeSeparator, separator(c))
+}
+
+func (c Container) MarshalJSON() ([]byte, error) {
+	type container Container
+	return marshalComponent(ComponentTypeContainer, container(c))
+}
+
+// NewActionRow returns an action row holding the passed components.
+func NewActionRow(components ...Component) ActionRow {
+	return ActionRow{Components: components}
+}
+
+// NewButton returns a button with the passed style, one of ButtonStylePrimary, ButtonStyleSecondary, ButtonStyleSuccess or ButtonStyleDanger.
+func NewButton(style int, label string, customID string) Button {
+	return Button{Style: style, Label: label, CustomID: customID}
+}
+
+// NewLinkButton returns a button that opens the passed URL.
+func NewLinkButton(label string, link string) Button {
+	return Button{Style: ButtonStyleLink, Label: label, URL: link}
+}
+
+// NewPremiumButton returns a button that prompts the user to buy the SKU with the passed ID.
+func NewPremiumButton(skuID Snowflake) Button {
+	return Button{Style: ButtonStylePremium, SKUID: skuID}
+}
+
+// NewStringSelect returns a select menu with the passed options.
+func NewStringSelect(customID string, options ...SelectOption) SelectMenu {
+	return SelectMenu{MenuType: ComponentTypeStringSelect, CustomID: customID, Options: options}
+}
+
+// NewUserSelect returns a select menu auto-populated with users.
+func NewUserSelect(customID string) SelectMenu {
+	return SelectMenu{MenuType: ComponentTypeUserSelect, CustomID: customID}
+}
+
+// NewRoleSelect returns a select menu auto-populated with roles.
+func NewRoleSelect(customID string) SelectMenu {
+	return SelectMenu{MenuType: ComponentTypeRoleSelect, CustomID: customID}
+}
+
+// NewMentionableSelect returns a select menu auto-populated with users & roles.
+func NewMentionableSelect(customID string) SelectMenu {
+	return SelectMenu{MenuType: ComponentTypeMentionableSelect, CustomID: customID}
+}
+
+// NewChannelSelect returns a select menu auto-populated with channels of the passed types, or of any type if none are passed.
+func NewChannelSelect(customID string, channelTypes ...int) SelectMenu {
+	return SelectMenu{MenuType: ComponentTypeChannelSelect, CustomID: customID, ChannelTypes: channelTypes}
+}
+
+// NewTextInput returns a text input with the passed style, one of TextInputStyleShort or TextInputStyleParagraph.
+func NewTextInput(customID string, label string, style int) TextInput {
+	return TextInput{CustomID: customID, Label: label, Style: style}
+}
+
+// NewModal returns a modal with each of the passed text inputs in its own action row.
+func NewModal(customID string, title string, inputs ...TextInput) Modal {
+	modal := Modal{CustomID: customID, Title: title}
+	for _, input := range inputs {
+		modal.Components = append(modal.Components, NewActionRow(input))
+	}
+	return modal
+}
+
+// NewSection returns a section showing the passed texts next to the accessory, a Button or a Thumbnail.
+func NewSection(accessory Component, texts ...string) Section {
+	section := Section{Accessory: accessory}
+	for _, text := range texts {
+		section.Components = append(section.Components, TextDisplay{Content: text})
+	}
+	return section
+}
+
+// NewContainer returns a container holding the passed components.
+func NewContainer(components ...Component) Container {
+	return Container{Components: components}
+}
+
+// componentValue returns the component a pointer component points to, so *Button & Button can be validated alike. It returns an error
+// if the component or the pointer is nil.
+func componentValue(component Component) (Component, error) {
+	if component == nil {
+		return nil, invalidComponents("component is nil")
+	}
+	v := reflect.ValueOf(component)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, invalidComponents("component is a nil %T", component)
+		}
+		return v.Elem().Interface().(Component), nil
+	}
+	return component, nil
+}
+
+// componentsFlags returns MessageFlagIsComponentsV2 if the passed components include layout components. Nil components are ignored.
+func componentsFlags(components []Component) int {
+	for _, component := range components {
+		value, err := componentValue(component)
+		if err != nil {
+			continue
+		}
+		if _, ok := value.(ActionRow); !ok {
+			return MessageFlagIsComponentsV2
+		}
+	}
+	return 0
+}
+
+// componentValidator validates components while tracking limits that apply to the whole message.
+type componentValidator struct {
+	customIDs  map[string]bool
+	count      int
+	textLength int
+}
+
+func invalidComponents(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidComponents, fmt.Sprintf(format, args...))
+}
+
+func checkLength(field string, value string, min int, max int) error {
+	length := utf8.RuneCountInString(value)
+	if length < min || length > max {
+		return invalidComponents("%s must be %d to %d characters, got %d", field, min, max, length)
+	}
+	return nil
+}
+
+// ValidateMessageComponents returns an error if the passed components break Discord's limits for a message with the passed flags.
+//
+// Without MessageFlagIsComponentsV2 a message holds up to 5 action rows, each with up to 5 buttons or a single select menu. With it,
+// layout components can be used at the top level, up to 40 components in total with up to 4000 characters of text.
+//
+// Possible Errors:
+//   - ErrInvalidComponents: Returned if a limit is broken, the error describes which.
+func ValidateMessageComponents(components []Component, flags int) error {
+	v := componentValidator{customIDs: map[string]bool{}}
+	if flags&MessageFlagIsComponentsV2 != 0 {
+		err := v.validateLayout(components, true)
+		if err != nil {
+			return err
+		}
+		if v.count > MaxComponentsV2 {
+			return invalidComponents("%d components, the maximum is %d", v.count, MaxComponentsV2)
+		}
+		if v.textLength > MaxComponentsV2TextLength {
+			return invalidComponents("%d characters of text, the maximum is %d", v.textLength, MaxComponentsV2TextLength)
+		}
+		return nil
+	}
+	if len(components) > MaxActionRows {
+		return invalidComponents("%d action rows, the maximum is %d", len(components), MaxActionRows)
+	}
+	for _, component := range components {
+		value, err := componentValue(component)
+		if err != nil {
+			return err
+		}
+		row, ok := value.(ActionRow)
+		if !ok {
+			return invalidComponents("component of type %d requires MessageFlagIsComponentsV2", value.ComponentType())
+		}
+		err = v.validateRow(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateModal returns an error if the passed modal breaks Discord's limits.
+//
+// Possible Errors:
+//   - ErrInvalidComponents: Returned if a limit is broken, the error describes which.
+func ValidateModal(modal Modal) error {
+	v := componentValidator{customIDs: map[string]bool{}}
+	err := checkLength("modal custom id", modal.CustomID, 1, MaxCustomIDLength)
+	if err != nil {
+		return err
+	}
+	err = checkLength("modal title", modal.Title, 1, 45)
+	if err != nil {
+		return err
+	}
+	if len(modal.Components) == 0 || len(modal.Components) > MaxActionRows {
+		return invalidComponents("modal has %d action rows, it must have 1 to %d", len(modal.Components), MaxActionRows)
+	}
+	for _, component := range modal.Components {
+		value, err := componentValue(component)
+		if err != nil {
+			return err
+		}
+		row, ok := value.(ActionRow)
+		if !ok || len(row.Components) != 1 {
+			return invalidComponents("modal components must be action rows holding a single text input")
+		}
+		value, err = componentValue(row.Components[0])
+		if err != nil {
+			return err
+		}
+		input, ok := value.(TextInput)
+		if !ok {
+			return invalidComponents("modal components must be action rows holding a single text input")
+		}
+		err = v.validateTextInput(input)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *componentValidator) validateCustomID(customID string) error {
+	err := checkLength("custom id", customID, 1, MaxCustomIDLength)
+	if err != nil {
+		return err
+	}
+	if v.customIDs[customID] {
+		return invalidComponents("custom id %q is used twice", customID)
+	}
+	v.customIDs[customID] = true
+	return nil
+}
+
+func (v *componentValidator) validateRow(row ActionRow) error {
+	v.count += 1 + len(row.Components)
+	if len(row.Components) == 0 {
+		return invalidComponents("action row is empty")
+	}
+	buttons := 0
+	for _, component := range row.Components {
+		value, err := componentValue(component)
+		if err != nil {
+			return err
+		}
+		switch c := value.(type) {
+		case Button:
+			buttons++
+			err := v.validateButton(c)
+			if err != nil {
+				return err
+			}
+		case SelectMenu:
+			if len(row.Components) != 1 {
+				return invalidComponents("a select menu must be alone in its action row")
+			}
+			err := v.validateSelect(c)
+			if err != nil {
+				return err
+			}
+		default:
+			return invalidComponents("action rows in messages can only hold buttons & select menus, got type %d", value.ComponentType())
+		}
+	}
+	if buttons > MaxButtonsPerRow {
+		return invalidComponents("%d buttons in an action row, the maximum is %d", buttons, MaxButtonsPerRow)
+	}
+	return nil
+}
+
+func (v *componentValidator) validateButton(button Button) error {
+	err := checkLength("button label", button.Label, 0, 80)
+	if err != nil {
+		return err
+	}
+	switch button.Style {
+	case ButtonStylePrimary, ButtonStyleSecondary, ButtonStyleSuccess, ButtonStyleDanger:
+		if button.URL != "" || button.SKUID != 0 {
+			return invalidComponents("button of style %d can't have a url or sku id", button.Style)
+		}
+		err = v.validateCustomID(button.CustomID)
+	case ButtonStyleLink:
+		if button.URL == "" || button.CustomID != "" || button.SKUID != 0 {
+			return invalidComponents("link button must have a url & no custom id or sku id")
+		}
+		err = checkLength("button url", button.URL, 1, 512)
+	case ButtonStylePremium:
+		if button.SKUID == 0 || button.CustomID != "" || button.URL != "" || button.Label != "" || button.Emoji != nil {
+			return invalidComponents("premium button must have a sku id & no custom id, url, label or emoji")
+		}
+		return nil
+	default:
+		return invalidComponents("unknown button style %d", button.Style)
+	}
+	if err != nil {
+		return err
+	}
+	if button.Label == "" && button.Emoji == nil {
+		return invalidComponents("button must have a label or an emoji")
+	}
+	return nil
+}
+
+func (v *componentValidator) validateSelect(menu SelectMenu) error {
+	switch menu.MenuType {
+	case ComponentTypeStringSelect:
+		if len(menu.Options) == 0 || len(menu.Options) > MaxSelectOptions {
+			return invalidComponents("string select has %d options, it must have 1 to %d", len(menu.Options), MaxSelectOptions)
+		}
+	case ComponentTypeUserSelect, ComponentTypeRoleSelect, ComponentTypeMentionableSelect, ComponentTypeChannelSelect:
+		if len(menu.Options) > 0 {
+			return invalidComponents("only string selects can have options")
+		}
+	default:
+		return invalidComponents("unknown select menu type %d", menu.MenuType)
+	}
+	if len(menu.ChannelTypes) > 0 && menu.MenuType != ComponentTypeChannelSelect {
+		return invalidComponents("only channel selects can have channel types")
+	}
+	err := v.validateCustomID(menu.CustomID)
+	if err != nil {
+		return err
+	}
+	err = checkLength("select placeholder", menu.Placeholder, 0, 150)
+	if err != nil {
+		return err
+	}
+	if menu.MinValues != nil && (*menu.MinValues < 0 || *menu.MinValues > MaxSelectOptions) {
+		return invalidComponents("select min values must be 0 to %d, got %d", MaxSelectOptions, *menu.MinValues)
+	}
+	if menu.MaxValues < 0 || menu.MaxValues > MaxSelectOptions {
+		return invalidComponents("select max values must be 1 to %d, got %d", MaxSelectOptions, menu.MaxValues)
+	}
+	if menu.MinValues != nil && menu.MaxValues != 0 && *menu.MinValues > menu.MaxValues {
+		return invalidComponents("select min values %d is greater than max values %d", *menu.MinValues, menu.MaxValues)
+	}
+	if menu.MenuType == ComponentTypeStringSelect && menu.MaxValues > len(menu.Options) {
+		return invalidComponents("select max values %d is greater than its %d options", menu.MaxValues, len(menu.Options))
+	}
+	for _, option := range menu.Options {
+		for field, value := range map[string]string{"option label": option.Label, "option value": option.Value} {
+			err = checkLength(field, value, 1, 100)
+			if err != nil {
+				return err
+			}
+		}
+		err = checkLength("option description", option.Description, 0, 100)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *componentValidator) validateTextInput(input TextInput) error {
+	if input.Style != TextInputStyleShort && input.Style != TextInputStyleParagraph {
+		return invalidComponents("unknown text input style %d", input.Style)
+	}
+	err := v.validateCustomID(input.CustomID)
+	if err != nil {
+		return err
+	}
+	err = checkLength("text input label", input.Label, 1, 45)
+	if err != nil {
+		return err
+	}
+	if input.MinLength < 0 || input.MinLength > 4000 || input.MaxLength < 0 || input.MaxLength > 4000 {
+		return invalidComponents("text input lengths must be 0 to 4000")
+	}
+	if input.MaxLength != 0 && input.MinLength > input.MaxLength {
+		return invalidComponents("text input min length %d is greater than max length %d", input.MinLength, input.MaxLength)
+	}
+	err = checkLength("text input value", input.Value, 0, 4000)
+	if err != nil {
+		return err
+	}
+	return checkLength("text input placeholder", input.Placeholder, 0, 100)
+}
+
+// validateLayout validates the components of a message with MessageFlagIsComponentsV2 or of a container within one.
+func (v *componentValidator) validateLayout(components []Component, topLevel bool) error {
+	for _, component := range components {
+		value, err := componentValue(component)
+		if err != nil {
+			return err
+		}
+		switch c := value.(type) {
+		case ActionRow:
+			err = v.validateRow(c)
+		case Section:
+			v.count++
+			err = v.validateSection(c)
+		case TextDisplay:
+			v.count++
+			v.textLength += utf8.RuneCountInString(c.Content)
+		case MediaGallery:
+			v.count++
+			if len(c.Items) == 0 || len(c.Items) > 10 {
+				return invalidComponents("media gallery has %d items, it must have 1 to 10", len(c.Items))
+			}
+			for _, item := range c.Items {
+				if item.Media.URL == "" {
+					return invalidComponents("media gallery item must have a url")
+				}
+			}
+		case FileComponent:
+			v.count++
+			if c.File.URL == "" {
+				return invalidComponents("file must have an attachment:// url")
+			}
+		case Separator:
+			v.count++
+			if c.Spacing != 0 && c.Spacing != SeparatorSpacingSmall && c.Spacing != SeparatorSpacingLarge {
+				return invalidComponents("unknown separator spacing %d", c.Spacing)
+			}
+		case Container:
+			if !topLevel {
+				return invalidComponents("containers can't be nested")
+			}
+			v.count++
+			err = v.validateLayout(c.Components, false)
+		default:
+			return invalidComponents("component of type %d can't be used at the top level of a message", value.ComponentType())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *componentValidator) validateSection(section Section) error {
+	if len(section.Components) == 0 || len(section.Components) > 3 {
+		return invalidComponents("section has %d components, it must have 1 to 3 text displays", len(section.Components))
+	}
+	for _, component := range section.Components {
+		value, err := componentValue(component)
+		if err != nil {
+			return err
+		}
+		text, ok := value.(TextDisplay)
+		if !ok {
+			return invalidComponents("sections can only hold text displays")
+		}
+		v.count++
+		v.textLength += utf8.RuneCountInString(text.Content)
+	}
+	if section.Accessory == nil {
+		return invalidComponents("section must have an accessory")
+	}
+	v.count++
+	accessory, err := componentValue(section.Accessory)
+	if err != nil {
+		return err
+	}
+	switch accessory := accessory.(type) {
+	case Button:
+		return v.validateButton(accessory)
+	case Thumbnail:
+		if accessory.Media.URL == "" {
+			return invalidComponents("thumbnail must have a url")
+		}
+		return nil
+	}
+	return invalidComponents("section accessory must be a button or a thumbnail")
+}
@@ -1,8 +1,27 @@
 package discordapp
 
-// Constants
+import (
+	"net/http"
+	"time"
+)
+
+// BaseDiscordAPIURL is the base URL of Discord's API. Requests are sent to it unless a client's BaseURL is set, e.g. Bot.BaseURL.
 const BaseDiscordAPIURL = "https://discord.com/api"
 
+// HTTPClient is the client used to send requests to Discord's API unless a client's HTTPClient is set, e.g. Bot.HTTPClient. Its
+// Transport can be set to a HookTransport to log, trace or measure requests.
+var HTTPClient = &http.Client{Timeout: DefaultRequestTimeout}
+
+// DefaultRequestTimeout is the timeout of HTTPClient, including the time spent reading the response body.
+const DefaultRequestTimeout = 30 * time.Second
+
+// MaxRequestRetries is the number of times a rate limited request is resent after waiting for the duration Discord asks for, as long as
+// the wait is no longer than MaxRetryWait.
+const (
+	MaxRequestRetries = 3
+	MaxRetryWait      = 10 * time.Second
+)
+
 // Discord Scopes
 const (
 	// ScopeActivitiesRead allows your app to fetch data from a user's "Now Playing/Recently Played" list — not currently available for apps
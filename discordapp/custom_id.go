@@ -0,0 +1,86 @@
+package discordapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// customIDSignatureLength is the length of the base64 encoded signature at the end of a signed custom ID.
+const customIDSignatureLength = 11
+
+var customIDEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+// signCustomID returns the signature of the passed custom ID, truncated to 8 bytes to fit within the custom ID limit.
+func signCustomID(secret string, customID string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(customID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// EncodeCustomID packs the passed values into a custom ID starting with the passed prefix, so it can be routed with Router.Component
+// & Router.Modal, & signs it with the secret so users can't tamper with the values. The application's secret is a good choice of secret.
+//
+// The custom ID has the form {prefix}{value}:{value}:{signature}, with ":" & "%" in values escaped.
+//
+// Possible Errors:
+//   - ErrInvalidCustomID: Returned if the custom ID would be longer than 100 characters or the secret is empty.
+func EncodeCustomID(secret string, prefix string, values ...string) (string, error) {
+	if secret == "" {
+		return "", fmt.Errorf("%w: secret is empty", ErrInvalidCustomID)
+	}
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = customIDEscaper.Replace(value)
+	}
+	customID := prefix + strings.Join(escaped, ":") + ":"
+	customID += signCustomID(secret, customID)
+	if len(customID) > MaxCustomIDLength {
+		return "", fmt.Errorf("%w: custom id is %d characters, the maximum is %d", ErrInvalidCustomID, len(customID), MaxCustomIDLength)
+	}
+	return customID, nil
+}
+
+// DecodeCustomID verifies the signature of a custom ID created by EncodeCustomID with the same secret & prefix & returns its values.
+//
+// Possible Errors:
+//   - ErrInvalidCustomID: Returned if the custom ID doesn't start with the prefix, is malformed or its signature doesn't match.
+func DecodeCustomID(secret string, prefix string, customID string) ([]string, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("%w: secret is empty", ErrInvalidCustomID)
+	}
+	if !strings.HasPrefix(customID, prefix) || len(customID) < len(prefix)+customIDSignatureLength+1 {
+		return nil, fmt.Errorf("%w: malformed custom id", ErrInvalidCustomID)
+	}
+	signed, signature := customID[:len(customID)-customIDSignatureLength], customID[len(customID)-customIDSignatureLength:]
+	if !strings.HasSuffix(signed, ":") {
+		return nil, fmt.Errorf("%w: malformed custom id", ErrInvalidCustomID)
+	}
+	if !hmac.Equal([]byte(signature), []byte(signCustomID(secret, signed))) {
+		return nil, fmt.Errorf("%w: signature doesn't match", ErrInvalidCustomID)
+	}
+	payload := strings.TrimSuffix(signed[len(prefix):], ":")
+	if payload == "" {
+		return nil, nil
+	}
+	values := strings.Split(payload, ":")
+	for i, value := range values {
+		unescaped, err := url.PathUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed value", ErrInvalidCustomID)
+		}
+		values[i] = unescaped
+	}
+	return values, nil
+}
+
+// CustomIDValues returns the values packed into the custom ID of the routed component or modal by EncodeCustomID with the passed secret.
+//
+// It returns the same errors as DecodeCustomID.
+func (c *InteractionContext) CustomIDValues(secret string) ([]string, error) {
+	customID := c.Route + c.CustomIDSuffix
+	return DecodeCustomID(secret, c.Route, customID)
+}
@@ -0,0 +1,466 @@
+// Package discordtest provides an in-process fake of the parts of Discord's API used by discordapp, allowing tests to run offline.
+//
+//	server := discordtest.NewServer("token", "secret", discordapp.ApplicationInfo{ID: 1, Name: "Test"})
+//	defer server.Close()
+//	bot, err := discordapp.NewBotWithConfig("token", discordapp.BotConfig{BaseURL: server.URL})
+package discordtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+// Discord error codes returned by the server.
+const (
+	ErrorUnknownGuild       = 10004
+	ErrorUnknownMember      = 10007
+	ErrorUnknownUser        = 10013
+	ErrorMaxGuilds          = 30001
+	ErrorMissingPermissions = 50013
+	ErrorInvalidOAuth2Token = 50025
+)
+
+// accessTokenExpiresIn is the lifetime in seconds of the access tokens issued by the server.
+const accessTokenExpiresIn = 604800
+
+// Server is a fake Discord API server backed by in-memory state.
+//
+// The server emulates the application, guild, guild member, OAuth2 token, OAuth2 authorization & current user endpoints.
+// Unknown routes return a 404 response. State can be seeded & inspected at any time, the server is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, to be used as the BaseURL of a discordapp client.
+	URL string
+
+	server        *httptest.Server
+	mu            sync.Mutex
+	token         string
+	secret        string
+	application   discordapp.ApplicationInfo
+	guilds        map[discordapp.Snowflake]*discordapp.Guild
+	members       map[discordapp.Snowflake]map[discordapp.Snowflake]discordapp.Member
+	users         map[discordapp.Snowflake]discordapp.AuthorizedUser
+	accessTokens  map[string]discordapp.Snowflake
+	refreshTokens map[string]discordapp.Snowflake
+	codes         map[string]discordapp.Snowflake
+	errors        map[string]int
+	rateLimit     int
+	window        time.Duration
+	buckets       map[string]*bucket
+	issued        int
+}
+
+type bucket struct {
+	remaining int
+	reset     time.Time
+}
+
+// NewServer starts & returns a fake Discord API server accepting the passed bot token & client secret for the passed application.
+//
+// The server should be closed with Close when it is no longer needed.
+func NewServer(token string, secret string, application discordapp.ApplicationInfo) *Server {
+	s := &Server{
+		token:         token,
+		secret:        secret,
+		application:   application,
+		guilds:        map[discordapp.Snowflake]*discordapp.Guild{},
+		members:       map[discordapp.Snowflake]map[discordapp.Snowflake]discordapp.Member{},
+		users:         map[discordapp.Snowflake]discordapp.AuthorizedUser{},
+		accessTokens:  map[string]discordapp.Snowflake{},
+		refreshTokens: map[string]discordapp.Snowflake{},
+		codes:         map[string]discordapp.Snowflake{},
+		errors:        map[string]int{},
+		buckets:       map[string]*bucket{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddGuild adds the passed guild to the server, replacing any guild with the same ID.
+func (s *Server) AddGuild(guild discordapp.Guild) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.guilds[guild.ID] = &guild
+	if s.members[guild.ID] == nil {
+		s.members[guild.ID] = map[discordapp.Snowflake]discordapp.Member{}
+	}
+}
+
+// Guild returns the current state of the guild with the passed ID, or nil if it does not exist.
+func (s *Server) Guild(guildID discordapp.Snowflake) *discordapp.Guild {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	guild, ok := s.guilds[guildID]
+	if !ok {
+		return nil
+	}
+	copied := *guild
+	return &copied
+}
+
+// AddMember adds the passed member to the guild with the passed ID. The guild must have been added first.
+func (s *Server) AddMember(guildID discordapp.Snowflake, member discordapp.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.members[guildID] == nil {
+		s.members[guildID] = map[discordapp.Snowflake]discordapp.Member{}
+	}
+	s.members[guildID][member.User.ID] = member
+}
+
+// Member returns the member with the passed user ID in the guild with the passed guild ID, or nil if they are not a member.
+func (s *Server) Member(guildID discordapp.Snowflake, userID discordapp.Snowflake) *discordapp.Member {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	member, ok := s.members[guildID][userID]
+	if !ok {
+		return nil
+	}
+	return &member
+}
+
+// AddUser adds the passed user to the server & authorizes them with the passed access token. AccessToken can be "".
+func (s *Server) AddUser(user discordapp.AuthorizedUser, accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = user
+	if accessToken != "" {
+		s.accessTokens[accessToken] = user.ID
+	}
+}
+
+// AddAuthorizationCode adds an OAuth2 code that can be exchanged once for an access token of the user with the passed ID.
+func (s *Server) AddAuthorizationCode(code string, userID discordapp.Snowflake) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.codes[code] = userID
+}
+
+// SetError makes requests with the passed method & path, such as "PUT" & "/guilds/1/members/2", fail with the passed Discord error code.
+// A code of 0 removes the error.
+func (s *Server) SetError(method string, path string, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := method + " " + path
+	if code == 0 {
+		delete(s.errors, key)
+		return
+	}
+	s.errors[key] = code
+}
+
+// SetRateLimit limits every route to the passed number of requests per window.
+//
+// Responses include Discord's rate limit headers & requests over the limit receive a 429 response. A limit of 0 disables rate limiting.
+func (s *Server) SetRateLimit(limit int, window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimit = limit
+	s.window = window
+	s.buckets = map[string]*bucket{}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if !s.allow(w, r, segments) {
+		return
+	}
+	if code, ok := s.errors[r.Method+" "+r.URL.Path]; ok {
+		writeError(w, code)
+		return
+	}
+	route := r.Method + " /" + strings.Join(templateSegments(segments), "/")
+	switch route {
+	case "GET /oauth2/applications/@me":
+		if s.authorizeBot(w, r) {
+			writeJSON(w, http.StatusOK, s.application)
+		}
+	case "POST /oauth2/token":
+		s.exchangeToken(w, r)
+	case "GET /oauth2/@me":
+		if user, ok := s.authorizeBearer(w, r); ok {
+			writeJSON(w, http.StatusOK, s.authInfo(user))
+		}
+	case "GET /users/@me":
+		if user, ok := s.authorizeBearer(w, r); ok {
+			writeJSON(w, http.StatusOK, user)
+		}
+	case "GET /guilds/{id}":
+		if guild, ok := s.guild(w, r, segments[1]); ok {
+			response := *guild
+			if r.URL.Query().Get("with_counts") == "true" {
+				response.ApproximateMemberCount = len(s.members[guild.ID])
+			}
+			writeJSON(w, http.StatusOK, response)
+		}
+	case "PATCH /guilds/{id}":
+		if guild, ok := s.guild(w, r, segments[1]); ok {
+			err := merge(guild, r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			writeJSON(w, http.StatusOK, guild)
+		}
+	case "GET /guilds/{id}/preview":
+		if guild, ok := s.guild(w, r, segments[1]); ok {
+			var preview discordapp.GuildPreview
+			data, _ := json.Marshal(guild)
+			_ = json.Unmarshal(data, &preview)
+			preview.ApproximateMemberCount = len(s.members[guild.ID])
+			writeJSON(w, http.StatusOK, preview)
+		}
+	case "GET /guilds/{id}/members/{id}":
+		if guild, ok := s.guild(w, r, segments[1]); ok {
+			member, ok := s.members[guild.ID][parseID(segments[3])]
+			if !ok {
+				writeError(w, ErrorUnknownMember)
+				return
+			}
+			writeJSON(w, http.StatusOK, member)
+		}
+	case "PUT /guilds/{id}/members/{id}":
+		if guild, ok := s.guild(w, r, segments[1]); ok {
+			s.addGuildMember(w, r, guild, parseID(segments[3]))
+		}
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]any{"message": "404: Not Found", "code": 0})
+	}
+}
+
+// allow applies the rate limit of the request's route & writes the rate limit headers, returning false if the request is rate limited.
+func (s *Server) allow(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	if s.rateLimit <= 0 {
+		return true
+	}
+	key := r.Method + " /" + strings.Join(templateSegments(segments), "/")
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok || now.After(b.reset) {
+		b = &bucket{remaining: s.rateLimit, reset: now.Add(s.window)}
+		s.buckets[key] = b
+	}
+	resetAfter := b.reset.Sub(now).Seconds()
+	header := w.Header()
+	header.Set("X-RateLimit-Limit", strconv.Itoa(s.rateLimit))
+	header.Set("X-RateLimit-Reset", strconv.FormatFloat(float64(b.reset.UnixMilli())/1000, 'f', 3, 64))
+	header.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter, 'f', 3, 64))
+	header.Set("X-RateLimit-Bucket", base64.RawURLEncoding.EncodeToString([]byte(key)))
+	if b.remaining == 0 {
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Scope", "user")
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(resetAfter))))
+		writeJSON(w, http.StatusTooManyRequests, map[string]any{"message": "You are being rate limited.", "retry_after": resetAfter, "global": false})
+		return false
+	}
+	b.remaining--
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return true
+}
+
+func (s *Server) authorizeBot(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Authorization") != "Bot "+s.token {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"message": "401: Unauthorized", "code": 0})
+		return false
+	}
+	return true
+}
+
+func (s *Server) authorizeBearer(w http.ResponseWriter, r *http.Request) (discordapp.AuthorizedUser, bool) {
+	userID, ok := s.accessTokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"message": "401: Unauthorized", "code": 0})
+		return discordapp.AuthorizedUser{}, false
+	}
+	return s.users[userID], true
+}
+
+func (s *Server) guild(w http.ResponseWriter, r *http.Request, id string) (*discordapp.Guild, bool) {
+	if !s.authorizeBot(w, r) {
+		return nil, false
+	}
+	guild, ok := s.guilds[parseID(id)]
+	if !ok {
+		writeError(w, ErrorUnknownGuild)
+		return nil, false
+	}
+	return guild, true
+}
+
+func (s *Server) addGuildMember(w http.ResponseWriter, r *http.Request, guild *discordapp.Guild, userID discordapp.Snowflake) {
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tokenUserID, ok := s.accessTokens[body.AccessToken]
+	if !ok || tokenUserID != userID {
+		writeError(w, ErrorInvalidOAuth2Token)
+		return
+	}
+	if _, ok := s.members[guild.ID][userID]; ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	user := s.users[userID]
+	member := discordapp.Member{
+		JoinedAt: time.Now().UTC(),
+		User: discordapp.MemberUser{
+			ID:            user.ID,
+			Username:      user.Username,
+			Discriminator: user.Discriminator,
+			Avatar:        user.Avatar,
+		},
+	}
+	s.members[guild.ID][userID] = member
+	writeJSON(w, http.StatusCreated, member)
+}
+
+func (s *Server) exchangeToken(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	clientID, secret, ok := r.BasicAuth()
+	if !ok {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.application.ID.String() || secret != s.secret {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "invalid_client"})
+		return
+	}
+	var userID discordapp.Snowflake
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		userID, ok = s.codes[r.PostForm.Get("code")]
+		delete(s.codes, r.PostForm.Get("code"))
+	case "refresh_token":
+		userID, ok = s.refreshTokens[r.PostForm.Get("refresh_token")]
+		delete(s.refreshTokens, r.PostForm.Get("refresh_token"))
+	default:
+		ok = false
+	}
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+		return
+	}
+	s.issued++
+	accessToken := fmt.Sprintf("access-%d", s.issued)
+	refreshToken := fmt.Sprintf("refresh-%d", s.issued)
+	s.accessTokens[accessToken] = userID
+	s.refreshTokens[refreshToken] = userID
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token":  accessToken,
+		"token_type":    "Bearer",
+		"expires_in":    accessTokenExpiresIn,
+		"refresh_token": refreshToken,
+		"scope":         discordapp.ScopeIdentify,
+	})
+}
+
+func (s *Server) authInfo(user discordapp.AuthorizedUser) discordapp.AuthInfo {
+	var info discordapp.AuthInfo
+	info.Application.ID = s.application.ID
+	info.Application.Name = s.application.Name
+	info.Application.Icon = s.application.Icon
+	info.Application.Description = s.application.Description
+	info.Application.BotPublic = s.application.BotPublic
+	info.Application.BotRequireCodeGrant = s.application.BotRequireCodeGrant
+	info.Application.VerifyKey = s.application.VerifyKey
+	info.Scopes = []string{discordapp.ScopeIdentify}
+	info.Expires = time.Now().UTC().Add(accessTokenExpiresIn * time.Second)
+	info.User.ID = user.ID
+	info.User.Username = user.Username
+	info.User.Avatar = user.Avatar
+	info.User.Discriminator = user.Discriminator
+	info.User.PublicFlags = user.PublicFlags
+	return info
+}
+
+// templateSegments replaces the IDs in the passed path segments with "{id}".
+func templateSegments(segments []string) []string {
+	template := make([]string, len(segments))
+	for i, segment := range segments {
+		if _, err := strconv.ParseUint(segment, 10, 64); err == nil {
+			segment = "{id}"
+		}
+		template[i] = segment
+	}
+	return template
+}
+
+func parseID(id string) discordapp.Snowflake {
+	parsed, _ := discordapp.ParseSnowflake(id)
+	return parsed
+}
+
+// merge applies the fields of the json object in body to the passed value.
+func merge(value any, body io.Reader) error {
+	current, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(current, &fields)
+	if err != nil {
+		return err
+	}
+	err = json.NewDecoder(body).Decode(&fields)
+	if err != nil {
+		return err
+	}
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(merged, value)
+}
+
+// writeError writes the error response Discord returns for the passed error code.
+func writeError(w http.ResponseWriter, code int) {
+	status := http.StatusBadRequest
+	message := "Invalid Form Body"
+	switch code {
+	case ErrorUnknownGuild:
+		status, message = http.StatusNotFound, "Unknown Guild"
+	case ErrorUnknownMember:
+		status, message = http.StatusNotFound, "Unknown Member"
+	case ErrorUnknownUser:
+		status, message = http.StatusNotFound, "Unknown User"
+	case ErrorMaxGuilds:
+		message = "Maximum number of guilds reached (100)"
+	case ErrorMissingPermissions:
+		status, message = http.StatusForbidden, "Missing Permissions"
+	case ErrorInvalidOAuth2Token:
+		status, message = http.StatusForbidden, "Invalid OAuth2 access token"
+	}
+	writeJSON(w, status, map[string]any{"message": message, "code": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
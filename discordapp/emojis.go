@@ -0,0 +1,348 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kodishim/discordapp/discordapp/util"
+)
+
+// Emoji represents an emoji object returned by Discord's API.
+//
+// ID is 0 for standard unicode emojis. Roles are the IDs of the roles allowed to use the emoji, empty for everyone.
+type Emoji struct {
+	ID            Snowflake   `json:"id"`
+	Name          string      `json:"name"`
+	Roles         []Snowflake `json:"roles"`
+	User          *MemberUser `json:"user"`
+	RequireColons bool        `json:"require_colons"`
+	Managed       bool        `json:"managed"`
+	Animated      bool        `json:"animated"`
+	Available     bool        `json:"available"`
+}
+
+// ModifyGuildEmojiParams represents the fields that can be changed on a guild emoji. Nil fields are left unchanged.
+type ModifyGuildEmojiParams struct {
+	Name  *string      `json:"name,omitempty"`
+	Roles *[]Snowflake `json:"roles,omitempty"`
+}
+
+// ListGuildEmojis lists the emojis of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) ListGuildEmojis(guildID Snowflake) ([]Emoji, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/emojis", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var emojis []Emoji
+	resp, err := b.Request(req, &emojis)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		for _, emoji := range emojis {
+			b.State.SetEmoji(guildID, emoji)
+		}
+	}
+	return emojis, nil
+}
+
+// FetchGuildEmoji fetches the emoji with the passed emoji ID from the guild with the passed guild ID.
+//
+// If the bot has a State, an emoji cached within the state's MaxAge is returned without a request.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrEmojiNotFound: Returned if the emoji does not exist in the guild.
+func (b *Bot) FetchGuildEmoji(guildID Snowflake, emojiID Snowflake) (*Emoji, error) {
+	if b.State != nil {
+		if emoji, ok := b.State.guildEmoji(guildID, emojiID, b.State.config.MaxAge); ok {
+			return emoji, nil
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/emojis/"+emojiID.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	emoji, err := b.emojiRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if b.State != nil {
+		b.State.SetEmoji(guildID, *emoji)
+	}
+	return emoji, nil
+}
+
+// CreateGuildEmoji creates an emoji in the guild with the passed guild ID from the passed PNG, JPEG, GIF or WebP image.
+//
+// Roles are the IDs of the roles allowed to use the emoji & can be nil to allow everyone.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage expressions.
+func (b *Bot) CreateGuildEmoji(guildID Snowflake, name string, image []byte, roles []Snowflake) (*Emoji, error) {
+	payload := struct {
+		Name  string      `json:"name"`
+		Image string      `json:"image"`
+		Roles []Snowflake `json:"roles,omitempty"`
+	}{name, util.ImageDataURI(image), roles}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/emojis", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	emoji, err := b.emojiRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if b.State != nil {
+		b.State.SetEmoji(guildID, *emoji)
+	}
+	b.invalidateGuild(guildID)
+	return emoji, nil
+}
+
+// ModifyGuildEmoji modifies the emoji with the passed emoji ID in the guild with the passed guild ID & returns the updated emoji.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrEmojiNotFound: Returned if the emoji does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage expressions.
+func (b *Bot) ModifyGuildEmoji(guildID Snowflake, emojiID Snowflake, params ModifyGuildEmojiParams) (*Emoji, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/emojis/"+emojiID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	emoji, err := b.emojiRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if b.State != nil {
+		b.State.SetEmoji(guildID, *emoji)
+	}
+	b.invalidateGuild(guildID)
+	return emoji, nil
+}
+
+// DeleteGuildEmoji deletes the emoji with the passed emoji ID from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrEmojiNotFound: Returned if the emoji does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage expressions.
+func (b *Bot) DeleteGuildEmoji(guildID Snowflake, emojiID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/guilds/"+guildID.String()+"/emojis/"+emojiID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	err = b.deleteEmojiRequest(req)
+	if err != nil {
+		return err
+	}
+	if b.State != nil {
+		b.State.RemoveEmoji(emojiID)
+	}
+	b.invalidateGuild(guildID)
+	return nil
+}
+
+// ListApplicationEmojis lists the emojis owned by the bot's application. Application emojis can be used by the bot in any guild.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+func (b *Bot) ListApplicationEmojis() ([]Emoji, error) {
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/applications/"+applicationID.String()+"/emojis", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var respBody struct {
+		Items []Emoji `json:"items"`
+	}
+	resp, err := b.Request(req, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return respBody.Items, nil
+}
+
+// FetchApplicationEmoji fetches the application emoji with the passed emoji ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrEmojiNotFound: Returned if the emoji does not exist.
+func (b *Bot) FetchApplicationEmoji(emojiID Snowflake) (*Emoji, error) {
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/applications/"+applicationID.String()+"/emojis/"+emojiID.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.emojiRequest(req)
+}
+
+// CreateApplicationEmoji creates an application emoji from the passed PNG, JPEG, GIF or WebP image.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+func (b *Bot) CreateApplicationEmoji(name string, image []byte) (*Emoji, error) {
+	payload := struct {
+		Name  string `json:"name"`
+		Image string `json:"image"`
+	}{name, util.ImageDataURI(image)}
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/applications/"+applicationID.String()+"/emojis", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.emojiRequest(req)
+}
+
+// ModifyApplicationEmoji renames the application emoji with the passed emoji ID & returns the updated emoji.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrEmojiNotFound: Returned if the emoji does not exist.
+func (b *Bot) ModifyApplicationEmoji(emojiID Snowflake, name string) (*Emoji, error) {
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{name})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/applications/"+applicationID.String()+"/emojis/"+emojiID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.emojiRequest(req)
+}
+
+// DeleteApplicationEmoji deletes the application emoji with the passed emoji ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrEmojiNotFound: Returned if the emoji does not exist.
+func (b *Bot) DeleteApplicationEmoji(emojiID Snowflake) error {
+	applicationID, err := b.applicationID()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/applications/"+applicationID.String()+"/emojis/"+emojiID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	return b.deleteEmojiRequest(req)
+}
+
+func (b *Bot) emojiRequest(req *http.Request) (*Emoji, error) {
+	var emoji Emoji
+	resp, err := b.Request(req, &emoji)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 10014 {
+				return nil, ErrEmojiNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK && resp.Status != http.StatusCreated {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &emoji, nil
+}
+
+func (b *Bot) deleteEmojiRequest(req *http.Request) error {
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+			if discordErr.code == 10014 {
+				return ErrEmojiNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
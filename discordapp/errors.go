@@ -14,13 +14,46 @@ var ErrAlreadyInGuild = errors.New("already_in_guild")
 var ErrMaxGuilds = errors.New("max_guilds")
 var ErrInvalidAccessToken = errors.New("invalid_access_token")
 var ErrMissingPermissions = errors.New("missing_permissions")
+var ErrChannelNotFound = errors.New("channel_not_found")
+var ErrMessageNotFound = errors.New("message_not_found")
+var ErrWebhookNotFound = errors.New("webhook_not_found")
+var ErrInviteNotFound = errors.New("invite_not_found")
+var ErrEmojiNotFound = errors.New("emoji_not_found")
+var ErrStickerNotFound = errors.New("sticker_not_found")
+var ErrInvalidStickerFile = errors.New("invalid_sticker_file")
+var ErrScheduledEventNotFound = errors.New("scheduled_event_not_found")
+var ErrInvalidScheduledEvent = errors.New("invalid_scheduled_event")
+var ErrInvalidStatusTransition = errors.New("invalid_status_transition")
+var ErrAutoModerationRuleNotFound = errors.New("auto_moderation_rule_not_found")
+var ErrUnsupportedTriggerType = errors.New("unsupported_trigger_type")
+var ErrInvalidPruneDays = errors.New("invalid_prune_days")
+var ErrWidgetDisabled = errors.New("widget_disabled")
+var ErrGuildTemplateNotFound = errors.New("guild_template_not_found")
+var ErrGuildTemplateExists = errors.New("guild_template_exists")
+var ErrRoleNotFound = errors.New("role_not_found")
+var ErrInvalidGuildLayout = errors.New("invalid_guild_layout")
+var ErrInvalidImageFormat = errors.New("invalid_image_format")
+var ErrInvalidImageSize = errors.New("invalid_image_size")
+var ErrUnknownInteraction = errors.New("unknown_interaction")
+var ErrInvalidInteractionResponse = errors.New("invalid_interaction_response")
+var ErrInteractionAlreadyResponded = errors.New("interaction_already_responded")
+var ErrInteractionTokenExpired = errors.New("interaction_token_expired")
+var ErrInvalidCommandOption = errors.New("invalid_command_option")
+var ErrNoInteractionHandler = errors.New("no_interaction_handler")
+var ErrInvalidComponents = errors.New("invalid_components")
+var ErrInvalidCustomID = errors.New("invalid_custom_id")
+var ErrCommandNotFound = errors.New("command_not_found")
+var ErrInvalidCommandPermissions = errors.New("invalid_command_permissions")
+var ErrMissingScope = errors.New("missing_scope")
+var ErrCacheMiss = errors.New("cache_miss")
+var ErrApplicationMismatch = errors.New("application_mismatch")
 
 type UnexpectedResponseError struct {
 	response *util.Response
 }
 
 func (e *UnexpectedResponseError) Error() string {
-	return fmt.Sprintf("unexpected response: %d %s", e.response.Status, e.response.Body)
+	return fmt.Sprintf("unexpected response: %d %s", e.response.Status, scrubBody(e.response.Body))
 }
 
 type DiscordError struct {
@@ -1,102 +1,240 @@
 package discordapp
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/kodishim/discordapp/discordapp/util"
+)
+
+// Verification levels
+const (
+	VerificationLevelNone     = 0
+	VerificationLevelLow      = 1
+	VerificationLevelMedium   = 2
+	VerificationLevelHigh     = 3
+	VerificationLevelVeryHigh = 4
+)
+
+// Default message notification levels
+const (
+	MessageNotificationsAllMessages  = 0
+	MessageNotificationsOnlyMentions = 1
+)
+
+// Explicit content filter levels
+const (
+	ExplicitContentFilterDisabled            = 0
+	ExplicitContentFilterMembersWithoutRoles = 1
+	ExplicitContentFilterAllMembers          = 2
+)
+
+// Guild features that can be enabled or disabled when modifying a guild
+const (
+	GuildFeatureCommunity          = "COMMUNITY"
+	GuildFeatureDiscoverable       = "DISCOVERABLE"
+	GuildFeatureInvitesDisabled    = "INVITES_DISABLED"
+	GuildFeatureRaidAlertsDisabled = "RAID_ALERTS_DISABLED"
 )
 
 // GuildPreview represents a guild preview object returned by Discord's API
 type GuildPreview struct {
-	ID                       string   `json:"id"`
-	Name                     string   `json:"name"`
-	Icon                     string   `json:"icon"`
-	Splash                   any      `json:"splash"`
-	DiscoverySplash          any      `json:"discovery_splash"`
-	Emojis                   []any    `json:"emojis"`
-	Features                 []string `json:"features"`
-	ApproximateMemberCount   int      `json:"approximate_member_count"`
-	ApproximatePresenceCount int      `json:"approximate_presence_count"`
-	Description              string   `json:"description"`
-	Stickers                 []any    `json:"stickers"`
+	ID                       Snowflake `json:"id"`
+	Name                     string    `json:"name"`
+	Icon                     string    `json:"icon"`
+	Splash                   any       `json:"splash"`
+	DiscoverySplash          any       `json:"discovery_splash"`
+	Emojis                   []Emoji   `json:"emojis"`
+	Features                 []string  `json:"features"`
+	ApproximateMemberCount   int       `json:"approximate_member_count"`
+	ApproximatePresenceCount int       `json:"approximate_presence_count"`
+	Description              string    `json:"description"`
+	Stickers                 []Sticker `json:"stickers"`
 }
 
 // Guild represents a guild object returned by Discord's API
 type Guild struct {
-	ID                       string   `json:"id"`
-	Name                     string   `json:"name"`
-	Icon                     string   `json:"icon"`
-	Description              *string  `json:"description"`
-	Splash                   string   `json:"splash"`
-	DiscoverySplash          *string  `json:"discovery_splash"`
-	ApproximateMemberCount   int      `json:"approximate_member_count"`
-	ApproximatePresenceCount int      `json:"approximate_presence_count"`
-	Features                 []string `json:"features"`
-	Emojis                   []struct {
-		Name          string   `json:"name"`
-		Roles         []string `json:"roles"`
-		ID            string   `json:"id"`
-		RequireColons bool     `json:"require_colons"`
-		Managed       bool     `json:"managed"`
-		Animated      bool     `json:"animated"`
-		Available     bool     `json:"available"`
-	} `json:"emojis"`
-	Banner            string  `json:"banner"`
-	OwnerID           string  `json:"owner_id"`
-	ApplicationID     *string `json:"application_id"`
-	Region            *string `json:"region"`
-	AfkChannelID      *string `json:"afk_channel_id"`
-	AfkTimeout        int     `json:"afk_timeout"`
-	SystemChannelID   *string `json:"system_channel_id"`
-	WidgetEnabled     bool    `json:"widget_enabled"`
-	WidgetChannelID   string  `json:"widget_channel_id"`
-	VerificationLevel int     `json:"verification_level"`
-	Roles             []struct {
-		ID          string `json:"id"`
-		Name        string `json:"name"`
-		Permissions int    `json:"permissions"`
-		Position    int    `json:"position"`
-		Color       int    `json:"color"`
-		Hoist       bool   `json:"hoist"`
-		Managed     bool   `json:"managed"`
-		Mentionable bool   `json:"mentionable"`
-	} `json:"roles"`
-	DefaultMessageNotifications int     `json:"default_message_notifications"`
-	MfaLevel                    int     `json:"mfa_level"`
-	ExplicitContentFilter       int     `json:"explicit_content_filter"`
-	MaxPresences                *int    `json:"max_presences"`
-	MaxMembers                  int     `json:"max_members"`
-	MaxVideoChannelUsers        int     `json:"max_video_channel_users"`
-	VanityURLCode               string  `json:"vanity_url_code"`
-	PremiumTier                 int     `json:"premium_tier"`
-	PremiumSubscriptionCount    int     `json:"premium_subscription_count"`
-	SystemChannelFlags          int     `json:"system_channel_flags"`
-	PreferredLocale             string  `json:"preferred_locale"`
-	RulesChannelID              *string `json:"rules_channel_id"`
-	PublicUpdatesChannelID      *string `json:"public_updates_channel_id"`
-	SafetyAlertsChannelID       *string `json:"safety_alerts_channel_id"`
+	ID                          Snowflake  `json:"id"`
+	Name                        string     `json:"name"`
+	Icon                        string     `json:"icon"`
+	Description                 *string    `json:"description"`
+	Splash                      string     `json:"splash"`
+	DiscoverySplash             *string    `json:"discovery_splash"`
+	ApproximateMemberCount      int        `json:"approximate_member_count"`
+	ApproximatePresenceCount    int        `json:"approximate_presence_count"`
+	Features                    []string   `json:"features"`
+	Emojis                      []Emoji    `json:"emojis"`
+	Stickers                    []Sticker  `json:"stickers"`
+	Banner                      string     `json:"banner"`
+	OwnerID                     Snowflake  `json:"owner_id"`
+	ApplicationID               *Snowflake `json:"application_id"`
+	Region                      *string    `json:"region"`
+	AfkChannelID                *Snowflake `json:"afk_channel_id"`
+	AfkTimeout                  int        `json:"afk_timeout"`
+	SystemChannelID             *Snowflake `json:"system_channel_id"`
+	WidgetEnabled               bool       `json:"widget_enabled"`
+	WidgetChannelID             Snowflake  `json:"widget_channel_id"`
+	VerificationLevel           int        `json:"verification_level"`
+	Roles                       []Role     `json:"roles"`
+	Channels                    []Channel  `json:"channels"`
+	DefaultMessageNotifications int        `json:"default_message_notifications"`
+	MfaLevel                    int        `json:"mfa_level"`
+	ExplicitContentFilter       int        `json:"explicit_content_filter"`
+	MaxPresences                *int       `json:"max_presences"`
+	MaxMembers                  int        `json:"max_members"`
+	MaxVideoChannelUsers        int        `json:"max_video_channel_users"`
+	VanityURLCode               string     `json:"vanity_url_code"`
+	PremiumTier                 int        `json:"premium_tier"`
+	PremiumSubscriptionCount    int        `json:"premium_subscription_count"`
+	SystemChannelFlags          int        `json:"system_channel_flags"`
+	PreferredLocale             string     `json:"preferred_locale"`
+	RulesChannelID              *Snowflake `json:"rules_channel_id"`
+	PublicUpdatesChannelID      *Snowflake `json:"public_updates_channel_id"`
+	SafetyAlertsChannelID       *Snowflake `json:"safety_alerts_channel_id"`
+	PremiumProgressBarEnabled   bool       `json:"premium_progress_bar_enabled"`
+	Unavailable                 bool       `json:"unavailable"`
+}
+
+// Role represents a role object returned by Discord's API.
+type Role struct {
+	ID           Snowflake   `json:"id"`
+	Name         string      `json:"name"`
+	Permissions  Permissions `json:"permissions"`
+	Position     int         `json:"position"`
+	Color        int         `json:"color"`
+	Hoist        bool        `json:"hoist"`
+	Managed      bool        `json:"managed"`
+	Mentionable  bool        `json:"mentionable"`
+	Icon         *string     `json:"icon"`
+	UnicodeEmoji *string     `json:"unicode_emoji"`
+	Flags        int         `json:"flags"`
+}
+
+// ModifyGuildParams represents the fields that can be changed on a guild. Nil fields are left unchanged.
+//
+// Icon, Splash, DiscoverySplash & Banner are the raw bytes of the images. Features replaces the guild's mutable features,
+// see the GuildFeature constants.
+//
+// Fields are cleared by setting them to their zero value: a pointer to 0 removes the AFK, system, rules, public updates or safety alerts
+// channel, an empty non-nil slice removes the icon, splash, discovery splash or banner & a pointer to "" removes the description.
+type ModifyGuildParams struct {
+	Name                        *string
+	VerificationLevel           *int
+	DefaultMessageNotifications *int
+	ExplicitContentFilter       *int
+	AfkChannelID                *Snowflake
+	AfkTimeout                  *int
+	Icon                        []byte
+	OwnerID                     *Snowflake
+	Splash                      []byte
+	DiscoverySplash             []byte
+	Banner                      []byte
+	SystemChannelID             *Snowflake
+	SystemChannelFlags          *int
+	RulesChannelID              *Snowflake
+	PublicUpdatesChannelID      *Snowflake
+	PreferredLocale             *string
+	Features                    *[]string
+	Description                 *string
+	PremiumProgressBarEnabled   *bool
+	SafetyAlertsChannelID       *Snowflake
+}
+
+// MarshalJSON encodes the params as expected by Discord's API, converting images into data URIs.
+func (p ModifyGuildParams) MarshalJSON() ([]byte, error) {
+	type params struct {
+		Name                        *string             `json:"name,omitempty"`
+		VerificationLevel           *int                `json:"verification_level,omitempty"`
+		DefaultMessageNotifications *int                `json:"default_message_notifications,omitempty"`
+		ExplicitContentFilter       *int                `json:"explicit_content_filter,omitempty"`
+		AfkChannelID                *clearableSnowflake `json:"afk_channel_id,omitempty"`
+		AfkTimeout                  *int                `json:"afk_timeout,omitempty"`
+		Icon                        *clearableString    `json:"icon,omitempty"`
+		OwnerID                     *Snowflake          `json:"owner_id,omitempty"`
+		Splash                      *clearableString    `json:"splash,omitempty"`
+		DiscoverySplash             *clearableString    `json:"discovery_splash,omitempty"`
+		Banner                      *clearableString    `json:"banner,omitempty"`
+		SystemChannelID             *clearableSnowflake `json:"system_channel_id,omitempty"`
+		SystemChannelFlags          *int                `json:"system_channel_flags,omitempty"`
+		RulesChannelID              *clearableSnowflake `json:"rules_channel_id,omitempty"`
+		PublicUpdatesChannelID      *clearableSnowflake `json:"public_updates_channel_id,omitempty"`
+		PreferredLocale             *string             `json:"preferred_locale,omitempty"`
+		Features                    *[]string           `json:"features,omitempty"`
+		Description                 *clearableString    `json:"description,omitempty"`
+		PremiumProgressBarEnabled   *bool               `json:"premium_progress_bar_enabled,omitempty"`
+		SafetyAlertsChannelID       *clearableSnowflake `json:"safety_alerts_channel_id,omitempty"`
+	}
+	image := func(data []byte) *clearableString {
+		if data == nil {
+			return nil
+		}
+		var uri clearableString
+		if len(data) > 0 {
+			uri = clearableString(util.ImageDataURI(data))
+		}
+		return &uri
+	}
+	return json.Marshal(params{
+		Name:                        p.Name,
+		VerificationLevel:           p.VerificationLevel,
+		DefaultMessageNotifications: p.DefaultMessageNotifications,
+		ExplicitContentFilter:       p.ExplicitContentFilter,
+		AfkChannelID:                (*clearableSnowflake)(p.AfkChannelID),
+		AfkTimeout:                  p.AfkTimeout,
+		Icon:                        image(p.Icon),
+		OwnerID:                     p.OwnerID,
+		Splash:                      image(p.Splash),
+		DiscoverySplash:             image(p.DiscoverySplash),
+		Banner:                      image(p.Banner),
+		SystemChannelID:             (*clearableSnowflake)(p.SystemChannelID),
+		SystemChannelFlags:          p.SystemChannelFlags,
+		RulesChannelID:              (*clearableSnowflake)(p.RulesChannelID),
+		PublicUpdatesChannelID:      (*clearableSnowflake)(p.PublicUpdatesChannelID),
+		PreferredLocale:             p.PreferredLocale,
+		Features:                    p.Features,
+		Description:                 (*clearableString)(p.Description),
+		PremiumProgressBarEnabled:   p.PremiumProgressBarEnabled,
+		SafetyAlertsChannelID:       (*clearableSnowflake)(p.SafetyAlertsChannelID),
+	})
+}
+
+// clearableString is a string in a request payload that encodes "" as null, letting a pointer to "" in Modify params clear the field
+// while nil leaves it unchanged.
+type clearableString string
+
+// MarshalJSON encodes "" as null & other strings as json strings.
+func (s clearableString) MarshalJSON() ([]byte, error) {
+	if s == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(s))
 }
 
 // Member represents the object of a user within the context of a guild returned by Discord's API.
 type Member struct {
-	Avatar                     string     `json:"avatar"`
-	CommunicationDisabledUntil time.Time  `json:"communication_disabled_until"`
-	Flags                      int        `json:"flags"`
-	JoinedAt                   time.Time  `json:"joined_at"`
-	Nick                       string     `json:"nick"`
-	Pending                    bool       `json:"pending"`
-	PremiumSince               time.Time  `json:"premium_since"`
-	Roles                      []string   `json:"roles"`
-	UnusualDmActivityUntil     time.Time  `json:"unusual_dm_activity_until"`
-	User                       MemberUser `json:"user"`
-	Mute                       bool       `json:"mute"`
-	Deaf                       bool       `json:"deaf"`
+	Avatar                     string      `json:"avatar"`
+	CommunicationDisabledUntil time.Time   `json:"communication_disabled_until"`
+	Flags                      int         `json:"flags"`
+	JoinedAt                   time.Time   `json:"joined_at"`
+	Nick                       string      `json:"nick"`
+	Pending                    bool        `json:"pending"`
+	PremiumSince               time.Time   `json:"premium_since"`
+	Roles                      []Snowflake `json:"roles"`
+	UnusualDmActivityUntil     time.Time   `json:"unusual_dm_activity_until"`
+	User                       MemberUser  `json:"user"`
+	Mute                       bool        `json:"mute"`
+	Deaf                       bool        `json:"deaf"`
+	// Permissions is only set on members received with an interaction & includes channel overwrites.
+	Permissions Permissions `json:"permissions"`
 }
 
 type MemberUser struct {
-	ID                   string            `json:"id"`
+	ID                   Snowflake         `json:"id"`
 	Username             string            `json:"username"`
 	Avatar               string            `json:"avatar"`
 	Discriminator        string            `json:"discriminator"`
@@ -117,8 +255,8 @@ type MemberUser struct {
 //   - UnexpectedResponseError: Returned if an unexpected response was received.
 //   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
 //   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
-func (b *Bot) FetchGuildPreview(guildID string) (*GuildPreview, error) {
-	req, err := http.NewRequest(http.MethodGet, BaseDiscordAPIURL+"/guilds/"+guildID+"/preview", nil)
+func (b *Bot) FetchGuildPreview(guildID Snowflake) (*GuildPreview, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/preview", nil)
 	if err != nil {
 		return nil, fmt.Errorf("error forming request: %w", err)
 	}
@@ -141,13 +279,33 @@ func (b *Bot) FetchGuildPreview(guildID string) (*GuildPreview, error) {
 
 // FetchGuild fetches the guild object of the guild with the passed ID.
 //
+// The guild is fetched with counts so ApproximateMemberCount & ApproximatePresenceCount are populated. If the bot has a State, a guild
+// cached within the state's MaxAge, or failing that a guild in the bot's Cache, is returned without a request & its counts may be out
+// of date. Guilds stored without counts, such as those received from the gateway or returned by ModifyGuild, are fetched again.
+//
 // Possible Errors:
 //   - ErrUnauthorized: Returned if the bot's token is invalid.
 //   - UnexpectedResponseError: Returned if an unexpected response was received.
 //   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
 //   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
-func (b *Bot) FetchGuild(guildID string) (*Guild, error) {
-	req, err := http.NewRequest(http.MethodGet, BaseDiscordAPIURL+"/guilds/"+guildID, nil)
+func (b *Bot) FetchGuild(guildID Snowflake) (*Guild, error) {
+	if b.State != nil {
+		if guild, ok := b.State.guild(guildID, b.State.config.MaxAge); ok && guild.ApproximateMemberCount != 0 {
+			return guild, nil
+		}
+	}
+	if b.Cache != nil {
+		var guild Guild
+		if b.Cache.Get(GuildCacheKey(guildID), &guild) && guild.ApproximateMemberCount != 0 {
+			return &guild, nil
+		}
+	}
+	return b.fetchGuild(guildID)
+}
+
+// fetchGuild fetches the guild with the passed ID from Discord's API, bypassing the bot's State & Cache, & stores it in them.
+func (b *Bot) fetchGuild(guildID Snowflake) (*Guild, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"?with_counts=true", nil)
 	if err != nil {
 		return nil, fmt.Errorf("error forming request: %w", err)
 	}
@@ -165,19 +323,39 @@ func (b *Bot) FetchGuild(guildID string) (*Guild, error) {
 	if resp.Status != http.StatusOK {
 		return nil, &UnexpectedResponseError{resp}
 	}
+	if b.State != nil {
+		b.State.SetGuild(guild)
+	}
+	if b.Cache != nil {
+		b.Cache.Set(GuildCacheKey(guildID), guild)
+	}
 	return &guild, nil
 }
 
 // FetchGuildMember feches a member based on the passed member ID. The member must be in the guild with the passed guild ID.
 //
+// If the bot has a State, a member cached within the state's MaxAge, or failing that a member in the bot's Cache, is returned without a
+// request.
+//
 // Possible Errors:
 //   - ErrUnauthorized: Returned if the bot's token is invalid.
 //   - UnexpectedResponseError: Returned if an unexpected response was received.
 //   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
 //   - ErrUserNotFound: Returned if a user with the passed member ID could not be found in the guild.
 //   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
-func (b *Bot) FetchGuildMember(guildID string, memberID string) (*Member, error) {
-	req, err := http.NewRequest(http.MethodGet, BaseDiscordAPIURL+"/guilds/"+guildID+"/members/"+memberID, nil)
+func (b *Bot) FetchGuildMember(guildID Snowflake, memberID Snowflake) (*Member, error) {
+	if b.State != nil {
+		if member, ok := b.State.member(guildID, memberID, b.State.config.MaxAge); ok {
+			return member, nil
+		}
+	}
+	if b.Cache != nil {
+		var member Member
+		if b.Cache.Get(MemberCacheKey(guildID, memberID), &member) {
+			return &member, nil
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/members/"+memberID.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("error forming request: %w", err)
 	}
@@ -189,7 +367,7 @@ func (b *Bot) FetchGuildMember(guildID string, memberID string) (*Member, error)
 			if discordErr.code == 10004 {
 				return nil, ErrGuildNotFound
 			}
-			if discordErr.code == 10013 {
+			if discordErr.code == 10007 || discordErr.code == 10013 {
 				return nil, ErrUserNotFound
 			}
 		}
@@ -198,6 +376,12 @@ func (b *Bot) FetchGuildMember(guildID string, memberID string) (*Member, error)
 	if resp.Status != http.StatusOK {
 		return nil, &UnexpectedResponseError{resp}
 	}
+	if b.State != nil {
+		b.State.SetMember(guildID, member)
+	}
+	if b.Cache != nil {
+		b.Cache.Set(MemberCacheKey(guildID, memberID), member)
+	}
 	return &member, nil
 }
 
@@ -213,11 +397,11 @@ func (b *Bot) FetchGuildMember(guildID string, memberID string) (*Member, error)
 //   - ErrInvalidAccessToken: Returned if the access token is invalid.
 //   - ErrUserNotFound: Returned if a user with the passed member ID could not be found in the guild.
 //   - ErrMissingPermissions: Returned if the bot does not have the permission to create invites.
-func (b *Bot) AddMemberToGuild(accessToken string, userID string, guildID string) error {
+func (b *Bot) AddMemberToGuild(accessToken string, userID Snowflake, guildID Snowflake) error {
 	body := fmt.Sprintf(`{
 		"access_token": "%s"
 	}`, accessToken)
-	req, err := http.NewRequest(http.MethodPut, BaseDiscordAPIURL+"/guilds/"+guildID+"/members/"+userID, strings.NewReader(body))
+	req, err := http.NewRequest(http.MethodPut, b.apiURL()+"/guilds/"+guildID.String()+"/members/"+userID.String(), strings.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("error forming request: %w", err)
 	}
@@ -228,7 +412,7 @@ func (b *Bot) AddMemberToGuild(accessToken string, userID string, guildID string
 			if discordErr.code == 10004 {
 				return ErrGuildNotFound
 			}
-			if discordErr.code == 10013 {
+			if discordErr.code == 10007 || discordErr.code == 10013 {
 				return ErrUserNotFound
 			}
 			if discordErr.code == 30001 {
@@ -251,3 +435,101 @@ func (b *Bot) AddMemberToGuild(accessToken string, userID string, guildID string
 	}
 	return nil
 }
+
+// ModifyGuild modifies the guild with the passed guild ID & returns the updated guild.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ModifyGuild(guildID Snowflake, params ModifyGuildParams) (*Guild, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var guild Guild
+	resp, err := b.Request(req, &guild)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		b.State.SetGuild(guild)
+	}
+	if b.Cache != nil {
+		b.Cache.Set(GuildCacheKey(guildID), guild)
+	}
+	return &guild, nil
+}
+
+// LeaveGuild makes the bot leave the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) LeaveGuild(guildID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/users/@me/guilds/"+guildID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		b.State.RemoveGuild(guildID)
+	}
+	if b.Cache != nil {
+		b.Cache.Delete(GuildCacheKey(guildID))
+	}
+	return nil
+}
+
+// guildRequest makes a request to a guild endpoint expecting a 200 response, mapping the common guild error codes.
+func (b *Bot) guildRequest(req *http.Request, unmarshalTo any) error {
+	resp, err := b.Request(req, unmarshalTo)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package discordapp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes a request sent to Discord's API. It holds no credentials: Route has IDs & webhook or interaction tokens replaced
+// with placeholders & Err has the request's URL replaced with Route.
+//
+// Context is the request's context. Header starts empty & the headers hooks set on it before the request is sent are added to the
+// request, e.g. to propagate a trace's context. Retries is the number of times the request was resent after being rate limited, see
+// MaxRequestRetries; each attempt is passed to the hooks separately.
+//
+// Status, Latency, Bucket & Err are only set once the request completes. Latency is the time until the response headers were received
+// & Bucket is the rate limit bucket from the response's X-RateLimit-Bucket header.
+type RequestInfo struct {
+	Context context.Context
+	Header  http.Header
+	Method  string
+	Route   string
+	Status  int
+	Latency time.Duration
+	Bucket  string
+	Retries int
+	Err     error
+}
+
+// RequestHook is called before a request is sent. The returned function, if not nil, is called once the request completes.
+type RequestHook func(info RequestInfo) (after func(info RequestInfo))
+
+// HookTransport is an http.RoundTripper that calls its hooks around every request sent using Next, or http.DefaultTransport if Next is
+// nil. Set it as HTTPClient's Transport to observe every request the package sends.
+type HookTransport struct {
+	Next  http.RoundTripper
+	Hooks []RequestHook
+}
+
+// NewHookTransport creates & returns a pointer to a transport calling the passed hooks around requests sent using next.
+func NewHookTransport(next http.RoundTripper, hooks ...RequestHook) *HookTransport {
+	return &HookTransport{Next: next, Hooks: hooks}
+}
+
+// RoundTrip sends the passed request using the transport's Next, calling its hooks before & after.
+func (t *HookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	baseURL, _ := req.Context().Value(baseURLKey{}).(string)
+	info := RequestInfo{
+		Context: req.Context(),
+		Header:  http.Header{},
+		Method:  req.Method,
+		Route:   routeTemplate(req.URL, baseURL),
+	}
+	info.Retries, _ = req.Context().Value(retriesKey{}).(int)
+	afters := make([]func(RequestInfo), 0, len(t.Hooks))
+	for _, hook := range t.Hooks {
+		if after := hook(info); after != nil {
+			afters = append(afters, after)
+		}
+	}
+	if len(info.Header) > 0 {
+		// A RoundTripper must not modify the request it's passed, so the headers are added to a copy.
+		req = req.Clone(req.Context())
+		for name, values := range info.Header {
+			req.Header[name] = values
+		}
+	}
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	info.Latency = time.Since(start)
+	if err != nil {
+		info.Err = scrubURLError(err, req.URL, baseURL)
+	} else {
+		info.Status = resp.StatusCode
+		info.Bucket = resp.Header.Get("X-RateLimit-Bucket")
+	}
+	for _, after := range afters {
+		after(info)
+	}
+	return resp, err
+}
+
+// RouteTemplate returns the path of the passed URL relative to BaseDiscordAPIURL with IDs replaced with {id} & webhook & interaction
+// tokens replaced with {token}, e.g. /webhooks/{id}/{token}/messages/{id}.
+//
+// The routes passed to hooks are relative to the base URL the request was sent to instead, e.g. Bot.BaseURL, so they don't depend on
+// the deployment.
+func RouteTemplate(u *url.URL) string {
+	return routeTemplate(u, BaseDiscordAPIURL)
+}
+
+// routeTemplate returns the route of the passed URL like RouteTemplate, relative to the passed base URL. "" uses BaseDiscordAPIURL.
+func routeTemplate(u *url.URL, baseURL string) string {
+	if baseURL == "" {
+		baseURL = BaseDiscordAPIURL
+	}
+	path := u.Path
+	if base, err := url.Parse(baseURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if isSnowflake(segment) {
+			segments[i] = "{id}"
+		} else if i >= 2 && segments[i-1] == "{id}" && (segments[i-2] == "webhooks" || segments[i-2] == "interactions") {
+			segments[i] = "{token}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isSnowflake(segment string) bool {
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return segment != ""
+}
+
+// SlogHook returns a hook that logs every completed request to the passed logger, at level Info if it succeeded & Warn if it failed or
+// received a non-2xx response. Logger can be nil to use slog.Default.
+func SlogHook(logger *slog.Logger) RequestHook {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(RequestInfo) func(RequestInfo) {
+		return func(info RequestInfo) {
+			level := slog.LevelInfo
+			if info.Err != nil || info.Status < 200 || info.Status > 299 {
+				level = slog.LevelWarn
+			}
+			attrs := []slog.Attr{
+				slog.String("method", info.Method),
+				slog.String("route", info.Route),
+				slog.Int("status", info.Status),
+				slog.Duration("latency", info.Latency),
+				slog.String("bucket", info.Bucket),
+				slog.Int("retries", info.Retries),
+			}
+			if info.Err != nil {
+				attrs = append(attrs, slog.String("error", info.Err.Error()))
+			}
+			ctx := info.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			logger.LogAttrs(ctx, level, "discord api request", attrs...)
+		}
+	}
+}
+
+// RequestTracer is implemented by tracers such as a wrapper of an OpenTelemetry tracer. StartSpan is called before a request is sent with
+// a span name of the form "GET /guilds/{id}" & the returned function ends the span once the request completes. StartSpan can propagate
+// the span by injecting its context into info.Header, e.g. with an OpenTelemetry propagator & a propagation.HeaderCarrier.
+type RequestTracer interface {
+	StartSpan(ctx context.Context, name string, info RequestInfo) (end func(info RequestInfo))
+}
+
+// RequestMetrics is implemented by metric recorders such as a set of Prometheus counters & histograms. ObserveRequest is called once
+// for every completed request.
+type RequestMetrics interface {
+	ObserveRequest(info RequestInfo)
+}
+
+// TracingHook returns a hook that starts a span with the passed tracer for every request.
+func TracingHook(tracer RequestTracer) RequestHook {
+	return func(info RequestInfo) func(RequestInfo) {
+		ctx := info.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return tracer.StartSpan(ctx, info.Method+" "+info.Route, info)
+	}
+}
+
+// MetricsHook returns a hook that passes every completed request to the passed metrics.
+func MetricsHook(metrics RequestMetrics) RequestHook {
+	return func(RequestInfo) func(RequestInfo) {
+		return metrics.ObserveRequest
+	}
+}
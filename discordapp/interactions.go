@@ -0,0 +1,535 @@
+package discordapp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp/util"
+)
+
+// Interaction types
+const (
+	InteractionTypePing                           = 1
+	InteractionTypeApplicationCommand             = 2
+	InteractionTypeMessageComponent               = 3
+	InteractionTypeApplicationCommandAutocomplete = 4
+	InteractionTypeModalSubmit                    = 5
+)
+
+// Interaction callback types
+const (
+	InteractionCallbackTypePong                                 = 1
+	InteractionCallbackTypeChannelMessageWithSource             = 4
+	InteractionCallbackTypeDeferredChannelMessageWithSource     = 5
+	InteractionCallbackTypeDeferredUpdateMessage                = 6
+	InteractionCallbackTypeUpdateMessage                        = 7
+	InteractionCallbackTypeApplicationCommandAutocompleteResult = 8
+	InteractionCallbackTypeModal                                = 9
+)
+
+// InteractionResponseWindow is how long after an interaction is created its initial response must be sent.
+const InteractionResponseWindow = 3 * time.Second
+
+// InteractionTokenLifetime is how long after an interaction is created its token can be used to manage responses & follow-ups.
+const InteractionTokenLifetime = 15 * time.Minute
+
+// MaxAutocompleteChoices is the maximum number of choices in an autocomplete result.
+const MaxAutocompleteChoices = 25
+
+// allowedCallbackTypes maps each interaction type to the callback types that can respond to it.
+var allowedCallbackTypes = map[int][]int{
+	InteractionTypePing:                           {InteractionCallbackTypePong},
+	InteractionTypeApplicationCommand:             {InteractionCallbackTypeChannelMessageWithSource, InteractionCallbackTypeDeferredChannelMessageWithSource, InteractionCallbackTypeModal},
+	InteractionTypeMessageComponent:               {InteractionCallbackTypeChannelMessageWithSource, InteractionCallbackTypeDeferredChannelMessageWithSource, InteractionCallbackTypeDeferredUpdateMessage, InteractionCallbackTypeUpdateMessage, InteractionCallbackTypeModal},
+	InteractionTypeApplicationCommandAutocomplete: {InteractionCallbackTypeApplicationCommandAutocompleteResult},
+	InteractionTypeModalSubmit:                    {InteractionCallbackTypeChannelMessageWithSource, InteractionCallbackTypeDeferredChannelMessageWithSource, InteractionCallbackTypeDeferredUpdateMessage, InteractionCallbackTypeUpdateMessage},
+}
+
+// Interaction represents an interaction object received from the gateway or an interactions endpoint.
+//
+// Data is left raw as its shape depends on Type. Member is set for interactions in guilds & User for interactions in DMs.
+type Interaction struct {
+	ID             Snowflake       `json:"id"`
+	ApplicationID  Snowflake       `json:"application_id"`
+	Type           int             `json:"type"`
+	Data           json.RawMessage `json:"data"`
+	GuildID        Snowflake       `json:"guild_id"`
+	ChannelID      Snowflake       `json:"channel_id"`
+	Member         *Member         `json:"member"`
+	User           *MemberUser     `json:"user"`
+	Token          string          `json:"token"`
+	Version        int             `json:"version"`
+	Message        *Message        `json:"message"`
+	AppPermissions Permissions     `json:"app_permissions"`
+	Locale         string          `json:"locale"`
+	GuildLocale    string          `json:"guild_locale"`
+}
+
+// InteractionResponse represents an interaction response sent to Discord's API.
+//
+// Data depends on Type, e.g. InteractionMessageParams for InteractionCallbackTypeChannelMessageWithSource.
+type InteractionResponse struct {
+	Type  int    `json:"type"`
+	Data  any    `json:"data,omitempty"`
+	Files []File `json:"-"`
+}
+
+// InteractionMessageParams represents a message sent in response to an interaction or as a follow-up.
+//
+// If Ephemeral is true MessageFlagEphemeral is added to Flags so only the user who triggered the interaction can see the message.
+type InteractionMessageParams struct {
+	Content         string           `json:"content,omitempty"`
+	TTS             bool             `json:"tts,omitempty"`
+	Embeds          []Embed          `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Flags           int              `json:"flags,omitempty"`
+	Components      []Component      `json:"components,omitempty"`
+	Ephemeral       bool             `json:"-"`
+	Files           []File           `json:"-"`
+}
+
+// EditInteractionMessageParams represents the fields that can be changed on an interaction response or follow-up. Nil fields are left unchanged.
+//
+// Attachments lists the existing attachments the message keeps, the rest are removed. If it's nil the existing attachments are kept &
+// Files are appended to them. MessageFlagIsComponentsV2 is added to Flags if Components holds layout components.
+type EditInteractionMessageParams struct {
+	Content         *string          `json:"content,omitempty"`
+	Embeds          *[]Embed         `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Flags           *int             `json:"flags,omitempty"`
+	Components      *[]Component     `json:"components,omitempty"`
+	Attachments     *[]Attachment    `json:"attachments,omitempty"`
+	Files           []File           `json:"-"`
+}
+
+// ApplicationCommandOptionChoice represents a choice of an application command option or an autocomplete result.
+//
+// Value should be a string, an integer or a float matching the option's type.
+type ApplicationCommandOptionChoice struct {
+	Name              string            `json:"name"`
+	NameLocalizations map[string]string `json:"name_localizations,omitempty"`
+	Value             any               `json:"value"`
+}
+
+// withFlags returns the params with MessageFlagEphemeral added to Flags if Ephemeral is true.
+func (p InteractionMessageParams) withFlags() InteractionMessageParams {
+	if p.Ephemeral {
+		p.Flags |= MessageFlagEphemeral
+	}
+	return p
+}
+
+// InteractionClient responds to an interaction & manages its responses using the interaction's token for authentication.
+//
+// A bot token is not required. The client only rejects requests based on what it has observed itself: a second initial response after it
+// sent one & any request after Discord rejected the token as expired. The response windows aren't enforced locally, as the local clock
+// may be skewed, so ResponseDeadline & TokenExpiry are only estimates.
+type InteractionClient struct {
+	ApplicationID Snowflake
+	InteractionID Snowflake
+	// Type is the interaction's type, used to reject callback types that can't respond to it. 0 disables the check.
+	Type  int
+	Token Secret
+	// BaseURL is the base URL of Discord's API the client sends requests to. "" uses BaseDiscordAPIURL.
+	BaseURL string
+	// HTTPClient is the client the client sends requests with. nil uses the package's HTTPClient.
+	HTTPClient *http.Client
+
+	mu        sync.Mutex
+	responded bool
+	expired   bool
+}
+
+// NewInteractionClient creates & returns a pointer to an interaction client using the passed application ID, interaction ID & token.
+func NewInteractionClient(applicationID Snowflake, interactionID Snowflake, token string) *InteractionClient {
+	return &InteractionClient{ApplicationID: applicationID, InteractionID: interactionID, Token: Secret(token)}
+}
+
+// Client returns an interaction client for the interaction.
+func (i *Interaction) Client() *InteractionClient {
+	client := NewInteractionClient(i.ApplicationID, i.ID, i.Token)
+	client.Type = i.Type
+	return client
+}
+
+// ResponseDeadline returns the time by which the initial response must be sent.
+func (c *InteractionClient) ResponseDeadline() time.Time {
+	return c.InteractionID.Time().Add(InteractionResponseWindow)
+}
+
+// TokenExpiry returns the time at which the interaction's token expires.
+func (c *InteractionClient) TokenExpiry() time.Time {
+	return c.InteractionID.Time().Add(InteractionTokenLifetime)
+}
+
+// Responded returns true if the initial response has been sent.
+func (c *InteractionClient) Responded() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.responded
+}
+
+// MarkResponded records that the initial response was sent without the client, e.g. as the body of a reply to an interactions endpoint request.
+func (c *InteractionClient) MarkResponded() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responded = true
+}
+
+// Respond sends the initial response to the interaction. Only one initial response can be sent.
+//
+// Possible Errors:
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrInvalidInteractionResponse: Returned if the callback type can't respond to the interaction's type.
+//   - ErrInteractionAlreadyResponded: Returned if the interaction has already been responded to.
+//   - ErrUnknownInteraction: Returned if Discord doesn't know the interaction, usually because the response window of 3 seconds has passed.
+func (c *InteractionClient) Respond(response InteractionResponse) error {
+	if c.Type != 0 {
+		allowed := false
+		for _, callbackType := range allowedCallbackTypes[c.Type] {
+			if callbackType == response.Type {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%w: callback type %d can't respond to interaction type %d", ErrInvalidInteractionResponse, response.Type, c.Type)
+		}
+	}
+	c.mu.Lock()
+	if c.responded {
+		c.mu.Unlock()
+		return ErrInteractionAlreadyResponded
+	}
+	c.responded = true
+	c.mu.Unlock()
+	err := c.respond(response)
+	if err != nil && !errors.Is(err, ErrInteractionAlreadyResponded) {
+		c.mu.Lock()
+		c.responded = false
+		c.mu.Unlock()
+	}
+	return err
+}
+
+func (c *InteractionClient) respond(response InteractionResponse) error {
+	// The attachments referencing the files belong in the response's data rather than next to its type.
+	var data json.RawMessage
+	if response.Data != nil || len(response.Files) > 0 {
+		var err error
+		data, err = messagePayload(response.Data, response.Files, false)
+		if err != nil {
+			return fmt.Errorf("error forming request: %w", err)
+		}
+	}
+	payloadJSON, err := json.Marshal(struct {
+		Type int             `json:"type"`
+		Data json.RawMessage `json:"data,omitempty"`
+	}{response.Type, data})
+	if err != nil {
+		return fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := newPayloadRequest(http.MethodPost, c.apiURL()+"/interactions/"+c.InteractionID.String()+"/"+c.Token.Value()+"/callback", payloadJSON, response.Files)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := c.interactionRequest(req, nil)
+	if err != nil {
+		return err
+	}
+	if resp.Status != http.StatusNoContent && resp.Status != http.StatusOK {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
+
+// RespondMessage responds to the interaction with a message.
+//
+// It returns the same errors as Respond & ErrInvalidComponents if the components break Discord's limits.
+func (c *InteractionClient) RespondMessage(params InteractionMessageParams) error {
+	params = params.withFlags()
+	err := ValidateMessageComponents(params.Components, params.Flags)
+	if err != nil {
+		return err
+	}
+	return c.Respond(InteractionResponse{Type: InteractionCallbackTypeChannelMessageWithSource, Data: params, Files: params.Files})
+}
+
+// Defer acknowledges the interaction & shows a loading state. The message is sent later with EditOriginalResponse.
+//
+// If ephemeral is true the message will only be visible to the user who triggered the interaction.
+//
+// It returns the same errors as Respond.
+func (c *InteractionClient) Defer(ephemeral bool) error {
+	response := InteractionResponse{Type: InteractionCallbackTypeDeferredChannelMessageWithSource}
+	if ephemeral {
+		response.Data = InteractionMessageParams{Flags: MessageFlagEphemeral}
+	}
+	return c.Respond(response)
+}
+
+// DeferUpdate acknowledges a component or modal submit interaction without showing a loading state. The message can be edited later with EditOriginalResponse.
+//
+// It returns the same errors as Respond.
+func (c *InteractionClient) DeferUpdate() error {
+	return c.Respond(InteractionResponse{Type: InteractionCallbackTypeDeferredUpdateMessage})
+}
+
+// UpdateMessage responds to a component or modal submit interaction by editing the message the component is attached to.
+//
+// A message's visibility can't be changed, so Ephemeral must be false.
+//
+// It returns the same errors as Respond & ErrInvalidComponents if the components break Discord's limits.
+func (c *InteractionClient) UpdateMessage(params InteractionMessageParams) error {
+	if params.Ephemeral {
+		return fmt.Errorf("%w: an updated message can't be made ephemeral", ErrInvalidInteractionResponse)
+	}
+	err := ValidateMessageComponents(params.Components, params.Flags)
+	if err != nil {
+		return err
+	}
+	return c.Respond(InteractionResponse{Type: InteractionCallbackTypeUpdateMessage, Data: params, Files: params.Files})
+}
+
+// RespondAutocomplete responds to an autocomplete interaction with up to 25 choices. Choices can be empty to show no results.
+//
+// It returns the same errors as Respond.
+func (c *InteractionClient) RespondAutocomplete(choices []ApplicationCommandOptionChoice) error {
+	if len(choices) > MaxAutocompleteChoices {
+		return fmt.Errorf("%w: %d autocomplete choices, the maximum is %d", ErrInvalidInteractionResponse, len(choices), MaxAutocompleteChoices)
+	}
+	if choices == nil {
+		choices = []ApplicationCommandOptionChoice{}
+	}
+	data := struct {
+		Choices []ApplicationCommandOptionChoice `json:"choices"`
+	}{choices}
+	return c.Respond(InteractionResponse{Type: InteractionCallbackTypeApplicationCommandAutocompleteResult, Data: data})
+}
+
+// RespondModal responds to the interaction with a modal, see NewModal.
+//
+// It returns the same errors as Respond & ErrInvalidComponents if the modal breaks Discord's limits.
+func (c *InteractionClient) RespondModal(modal Modal) error {
+	err := ValidateModal(modal)
+	if err != nil {
+		return err
+	}
+	return c.Respond(InteractionResponse{Type: InteractionCallbackTypeModal, Data: modal})
+}
+
+// checkToken returns ErrInteractionTokenExpired if Discord already rejected the interaction's token as expired.
+func (c *InteractionClient) checkToken() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.expired {
+		return ErrInteractionTokenExpired
+	}
+	return nil
+}
+
+// apiURL returns the base URL the client sends requests to.
+func (c *InteractionClient) apiURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return BaseDiscordAPIURL
+}
+
+// httpClient returns the client the interaction client sends requests with.
+func (c *InteractionClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return HTTPClient
+}
+
+// webhookURL returns the URL of the interaction's webhook followed by the passed path.
+func (c *InteractionClient) webhookURL(path string) string {
+	return c.apiURL() + "/webhooks/" + c.ApplicationID.String() + "/" + c.Token.Value() + path
+}
+
+// interactionRequest sends the passed request without a bot token & maps the errors of interaction endpoints. Unlike request, a 401
+// response isn't turned into ErrUnauthorized as Discord rejects an expired interaction token with a 401 & the error code 50027.
+func (c *InteractionClient) interactionRequest(req *http.Request, unmarshalTo any) (*util.Response, error) {
+	resp, err := makeRequest(req, c.httpClient(), c.apiURL(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", scrubURLError(err, req.URL, c.apiURL()))
+	}
+	resp, err = readResponse(resp, unmarshalTo)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			switch discordErr.code {
+			case 10062:
+				return nil, ErrUnknownInteraction
+			case 40060:
+				return nil, ErrInteractionAlreadyResponded
+			case 50027:
+				c.mu.Lock()
+				c.expired = true
+				c.mu.Unlock()
+				return nil, ErrInteractionTokenExpired
+			case 10008:
+				return nil, ErrMessageNotFound
+			}
+		}
+		var unexpected *UnexpectedResponseError
+		if errors.As(err, &unexpected) && unexpected.response.Status == http.StatusUnauthorized {
+			return nil, ErrUnauthorized
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	return resp, nil
+}
+
+// fetchMessage fetches the interaction message at the passed path, "/messages/@original" or "/messages/{message.id}".
+func (c *InteractionClient) fetchMessage(path string) (*Message, error) {
+	err := c.checkToken()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, c.webhookURL(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var message Message
+	resp, err := c.interactionRequest(req, &message)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &message, nil
+}
+
+func (c *InteractionClient) editMessage(path string, params EditInteractionMessageParams) (*Message, error) {
+	err := c.checkToken()
+	if err != nil {
+		return nil, err
+	}
+	if params.Components != nil {
+		flags := componentsFlags(*params.Components)
+		if params.Flags != nil {
+			flags |= *params.Flags
+		}
+		err = ValidateMessageComponents(*params.Components, flags)
+		if err != nil {
+			return nil, err
+		}
+		if flags&MessageFlagIsComponentsV2 != 0 {
+			params.Flags = &flags
+		}
+	}
+	req, err := newMessageRequest(http.MethodPatch, c.webhookURL(path), params, params.Files)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var message Message
+	resp, err := c.interactionRequest(req, &message)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &message, nil
+}
+
+func (c *InteractionClient) deleteMessage(path string) error {
+	err := c.checkToken()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodDelete, c.webhookURL(path), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := c.interactionRequest(req, nil)
+	if err != nil {
+		return err
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
+
+// FetchOriginalResponse fetches the initial response to the interaction.
+//
+// Possible Errors:
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrInteractionTokenExpired: Returned if the interaction's token has expired after 15 minutes.
+//   - ErrMessageNotFound: Returned if the response has been deleted or was not a message.
+func (c *InteractionClient) FetchOriginalResponse() (*Message, error) {
+	return c.fetchMessage("/messages/@original")
+}
+
+// EditOriginalResponse edits the initial response to the interaction & returns the edited message. It also sends the message of a deferred response.
+//
+// It returns the same errors as FetchOriginalResponse & ErrInvalidComponents if the components break Discord's limits.
+func (c *InteractionClient) EditOriginalResponse(params EditInteractionMessageParams) (*Message, error) {
+	return c.editMessage("/messages/@original", params)
+}
+
+// DeleteOriginalResponse deletes the initial response to the interaction.
+//
+// It returns the same errors as FetchOriginalResponse.
+func (c *InteractionClient) DeleteOriginalResponse() error {
+	return c.deleteMessage("/messages/@original")
+}
+
+// CreateFollowUp sends a follow-up message for the interaction & returns the created message.
+//
+// It returns the same errors as FetchOriginalResponse & ErrInvalidComponents if the components break Discord's limits.
+func (c *InteractionClient) CreateFollowUp(params InteractionMessageParams) (*Message, error) {
+	err := c.checkToken()
+	if err != nil {
+		return nil, err
+	}
+	params = params.withFlags()
+	err = ValidateMessageComponents(params.Components, params.Flags)
+	if err != nil {
+		return nil, err
+	}
+	req, err := newMessageRequest(http.MethodPost, c.webhookURL("?wait=true"), params, params.Files)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var message Message
+	resp, err := c.interactionRequest(req, &message)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &message, nil
+}
+
+// FetchFollowUp fetches the follow-up message with the passed message ID.
+//
+// It returns the same errors as FetchOriginalResponse.
+func (c *InteractionClient) FetchFollowUp(messageID Snowflake) (*Message, error) {
+	return c.fetchMessage("/messages/" + messageID.String())
+}
+
+// EditFollowUp edits the follow-up message with the passed message ID & returns the edited message.
+//
+// It returns the same errors as FetchOriginalResponse & ErrInvalidComponents if the components break Discord's limits.
+func (c *InteractionClient) EditFollowUp(messageID Snowflake, params EditInteractionMessageParams) (*Message, error) {
+	return c.editMessage("/messages/"+messageID.String(), params)
+}
+
+// DeleteFollowUp deletes the follow-up message with the passed message ID.
+//
+// It returns the same errors as FetchOriginalResponse.
+func (c *InteractionClient) DeleteFollowUp(messageID Snowflake) error {
+	return c.deleteMessage("/messages/" + messageID.String())
+}
@@ -0,0 +1,225 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Invite target types
+const (
+	InviteTargetTypeStream              = 1
+	InviteTargetTypeEmbeddedApplication = 2
+)
+
+// Invite represents an invite object returned by Discord's API.
+//
+// ApproximatePresenceCount & ApproximateMemberCount are only populated when fetched with counts. ExpiresAt is nil if the invite never expires.
+type Invite struct {
+	Type                     int              `json:"type"`
+	Code                     string           `json:"code"`
+	Guild                    *Guild           `json:"guild"`
+	Channel                  *Channel         `json:"channel"`
+	Inviter                  *MemberUser      `json:"inviter"`
+	TargetType               int              `json:"target_type"`
+	TargetUser               *MemberUser      `json:"target_user"`
+	TargetApplication        *ApplicationInfo `json:"target_application"`
+	ApproximatePresenceCount int              `json:"approximate_presence_count"`
+	ApproximateMemberCount   int              `json:"approximate_member_count"`
+	ExpiresAt                *time.Time       `json:"expires_at"`
+}
+
+// InviteMetadata represents an invite object along with its metadata, returned when creating or listing invites.
+type InviteMetadata struct {
+	Invite
+	Uses      int       `json:"uses"`
+	MaxUses   int       `json:"max_uses"`
+	MaxAge    int       `json:"max_age"`
+	Temporary bool      `json:"temporary"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateInviteParams represents the options used when creating an invite.
+//
+// MaxAge is the duration of the invite in seconds, 0 for never. If MaxAge is nil Discord's default of 24 hours is used.
+// MaxUses is the max number of uses, 0 for unlimited. Temporary invites grant temporary membership.
+// If Unique is false Discord may return an existing invite with the same options.
+type CreateInviteParams struct {
+	MaxAge              *int      `json:"max_age,omitempty"`
+	MaxUses             int       `json:"max_uses,omitempty"`
+	Temporary           bool      `json:"temporary,omitempty"`
+	Unique              bool      `json:"unique,omitempty"`
+	TargetType          int       `json:"target_type,omitempty"`
+	TargetUserID        Snowflake `json:"target_user_id,omitempty"`
+	TargetApplicationID Snowflake `json:"target_application_id,omitempty"`
+}
+
+// CreateChannelInvite creates an invite to the channel with the passed channel ID.
+//
+// This is useful as a fallback when AddMemberToGuild returns ErrMaxGuilds or the user has not granted the guilds.join scope.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to create invites.
+func (b *Bot) CreateChannelInvite(channelID Snowflake, params CreateInviteParams) (*InviteMetadata, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/channels/"+channelID.String()+"/invites", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var invite InviteMetadata
+	resp, err := b.Request(req, &invite)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &invite, nil
+}
+
+// ListGuildInvites lists the invites of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ListGuildInvites(guildID Snowflake) ([]InviteMetadata, error) {
+	return b.listInvites(b.apiURL() + "/guilds/" + guildID.String() + "/invites")
+}
+
+// ListChannelInvites lists the invites of the channel with the passed channel ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the channel.
+func (b *Bot) ListChannelInvites(channelID Snowflake) ([]InviteMetadata, error) {
+	return b.listInvites(b.apiURL() + "/channels/" + channelID.String() + "/invites")
+}
+
+func (b *Bot) listInvites(link string) ([]InviteMetadata, error) {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var invites []InviteMetadata
+	resp, err := b.Request(req, &invites)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return invites, nil
+}
+
+// FetchInvite resolves the invite with the passed code.
+//
+// If withCounts is true the approximate member & presence counts are populated. If withExpiration is true ExpiresAt is populated.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrInviteNotFound: Returned if the invite does not exist or has expired.
+func (b *Bot) FetchInvite(code string, withCounts bool, withExpiration bool) (*Invite, error) {
+	query := url.Values{}
+	if withCounts {
+		query.Set("with_counts", "true")
+	}
+	if withExpiration {
+		query.Set("with_expiration", "true")
+	}
+	link := b.apiURL() + "/invites/" + url.PathEscape(code)
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var invite Invite
+	resp, err := b.Request(req, &invite)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10006 {
+				return nil, ErrInviteNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &invite, nil
+}
+
+// DeleteInvite deletes the invite with the passed code & returns the deleted invite.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrInviteNotFound: Returned if the invite does not exist or has expired.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the invite's channel or guild.
+func (b *Bot) DeleteInvite(code string) (*Invite, error) {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/invites/"+url.PathEscape(code), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var invite Invite
+	resp, err := b.Request(req, &invite)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10006 {
+				return nil, ErrInviteNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &invite, nil
+}
@@ -0,0 +1,1233 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EveryoneRoleName is the name used to refer to a guild's @everyone role in a GuildLayout.
+const EveryoneRoleName = "@everyone"
+
+// Guild layout change actions
+const (
+	LayoutActionCreate = "create"
+	LayoutActionUpdate = "update"
+	LayoutActionDelete = "delete"
+)
+
+// Guild layout change kinds
+const (
+	LayoutKindSettings  = "settings"
+	LayoutKindRole      = "role"
+	LayoutKindChannel   = "channel"
+	LayoutKindPositions = "positions"
+)
+
+// GuildLayout is a stable, serializable description of a guild's settings, roles & channels, meant to be kept in version control.
+//
+// Objects are referred to by name rather than ID so a layout can be applied to any guild.
+// Roles are ordered from highest to lowest, managed roles such as bot roles are not included.
+// Channels contains the guild's categories & uncategorized channels, categories containing their own channels.
+// The order of roles & channels is applied to the guild, though Discord always lists uncategorized channels above categories & voice
+// channels below the other channels of their category.
+//
+// Layouts are encoded as JSON. A YAML document can be applied by converting it to JSON first, e.g. with sigs.k8s.io/yaml, as the
+// package doesn't depend on a YAML library.
+type GuildLayout struct {
+	Settings GuildLayoutSettings `json:"settings"`
+	Roles    []LayoutRole        `json:"roles"`
+	Channels []LayoutChannel     `json:"channels"`
+}
+
+// GuildLayoutSettings represents the settings of a guild in a GuildLayout. Channels are referred to by name & can be nil.
+// AfkChannel refers to a voice channel & the other channels refer to text channels.
+//
+// A name refers to the uncategorized channel with that name, or failing that the only channel with that name. Channels whose name is
+// used in several categories are referred to as "Category/name".
+type GuildLayoutSettings struct {
+	Name                        string  `json:"name"`
+	Description                 *string `json:"description"`
+	VerificationLevel           int     `json:"verification_level"`
+	DefaultMessageNotifications int     `json:"default_message_notifications"`
+	ExplicitContentFilter       int     `json:"explicit_content_filter"`
+	AfkTimeout                  int     `json:"afk_timeout"`
+	AfkChannel                  *string `json:"afk_channel"`
+	SystemChannel               *string `json:"system_channel"`
+	RulesChannel                *string `json:"rules_channel"`
+	PublicUpdatesChannel        *string `json:"public_updates_channel"`
+	PreferredLocale             string  `json:"preferred_locale"`
+}
+
+// LayoutRole represents a role in a GuildLayout. The @everyone role is named EveryoneRoleName.
+//
+// RenamedFrom can be set to the role's previous name to rename it rather than deleting it & creating a new role.
+type LayoutRole struct {
+	Name        string      `json:"name"`
+	RenamedFrom string      `json:"renamed_from,omitempty"`
+	Permissions Permissions `json:"permissions"`
+	Color       int         `json:"color"`
+	Hoist       bool        `json:"hoist"`
+	Mentionable bool        `json:"mentionable"`
+}
+
+// LayoutChannel represents a category or channel in a GuildLayout.
+//
+// Channels are matched to a guild's channels by category, name & type, so the same name can be used in different categories.
+// RenamedFrom can be set to the channel's previous name to rename it rather than deleting it & creating a new channel, keeping its
+// messages. Bitrate is left unchanged when 0. Channels is only used by categories.
+type LayoutChannel struct {
+	Name             string            `json:"name"`
+	RenamedFrom      string            `json:"renamed_from,omitempty"`
+	Type             int               `json:"type"`
+	Topic            string            `json:"topic,omitempty"`
+	NSFW             bool              `json:"nsfw,omitempty"`
+	Bitrate          int               `json:"bitrate,omitempty"`
+	UserLimit        int               `json:"user_limit,omitempty"`
+	RateLimitPerUser int               `json:"rate_limit_per_user,omitempty"`
+	Overwrites       []LayoutOverwrite `json:"overwrites,omitempty"`
+	Channels         []LayoutChannel   `json:"channels,omitempty"`
+}
+
+// LayoutOverwrite represents a permission overwrite in a GuildLayout.
+//
+// Exactly one of Role, the name of a role, & Member, the ID of a member, is set.
+type LayoutOverwrite struct {
+	Role   string      `json:"role,omitempty"`
+	Member Snowflake   `json:"member,omitempty"`
+	Allow  Permissions `json:"allow"`
+	Deny   Permissions `json:"deny"`
+}
+
+// GuildLayoutPlan represents the changes needed to make a guild match a GuildLayout, in the order they are applied.
+type GuildLayoutPlan struct {
+	Changes []GuildLayoutChange `json:"changes"`
+}
+
+// GuildLayoutChange represents a single change in a GuildLayoutPlan.
+//
+// ID is the ID of the changed role or channel & is 0 for creations, settings & positions. Fields lists the fields changed by updates.
+// Parent is the name of the category of a channel, nil for uncategorized channels.
+// Role, Channel, Settings or Order holds the desired state depending on Kind & is nil for deletions.
+type GuildLayoutChange struct {
+	Action   string               `json:"action"`
+	Kind     string               `json:"kind"`
+	ID       Snowflake            `json:"id,omitempty"`
+	Name     string               `json:"name"`
+	Parent   *string              `json:"parent,omitempty"`
+	Fields   []string             `json:"fields,omitempty"`
+	Role     *LayoutRole          `json:"role,omitempty"`
+	Channel  *LayoutChannel       `json:"channel,omitempty"`
+	Settings *GuildLayoutSettings `json:"settings,omitempty"`
+	Order    *LayoutOrder         `json:"order,omitempty"`
+}
+
+// LayoutOrder is the desired order of a guild's roles & channels held by a positions change.
+//
+// Roles holds the names of the roles from highest to lowest, without EveryoneRoleName. Channels holds the names & types of the layout's
+// channels in order, categories containing their own channels.
+type LayoutOrder struct {
+	Roles    []string        `json:"roles,omitempty"`
+	Channels []LayoutChannel `json:"channels,omitempty"`
+}
+
+// String returns a one line description of the change, e.g. "~ channel Text/general (topic, overwrites)".
+func (c GuildLayoutChange) String() string {
+	symbol := map[string]string{LayoutActionCreate: "+", LayoutActionUpdate: "~", LayoutActionDelete: "-"}[c.Action]
+	name := c.Name
+	if c.Parent != nil {
+		name = *c.Parent + "/" + name
+	}
+	line := symbol + " " + c.Kind
+	if c.Kind != LayoutKindSettings && c.Kind != LayoutKindPositions {
+		line += " " + name
+	}
+	if len(c.Fields) > 0 {
+		line += " (" + strings.Join(c.Fields, ", ") + ")"
+	}
+	return line
+}
+
+// Empty reports whether the plan has no changes.
+func (p *GuildLayoutPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns the plan's changes, one per line.
+func (p *GuildLayoutPlan) String() string {
+	var lines []string
+	for _, change := range p.Changes {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseGuildLayout decodes & validates a JSON encoded GuildLayout.
+//
+// Possible Errors:
+//   - ErrInvalidGuildLayout: Returned if the document has unknown fields or the layout is invalid.
+func ParseGuildLayout(data []byte) (*GuildLayout, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var layout GuildLayout
+	err := decoder.Decode(&layout)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidGuildLayout, err)
+	}
+	err = layout.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// Validate checks that the layout's names are set & unique, categories only contain channels, settings refer to existing channels of the
+// right type & previous names don't belong to other roles or channels of the layout.
+//
+// Possible Errors:
+//   - ErrInvalidGuildLayout: Returned if the layout is invalid.
+func (l *GuildLayout) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidGuildLayout, fmt.Sprintf(format, args...))
+	}
+	if l.Settings.Name == "" {
+		return invalid("guild name is empty")
+	}
+	roles := map[string]bool{}
+	for _, role := range l.Roles {
+		if role.Name == "" {
+			return invalid("role name is empty")
+		}
+		if roles[role.Name] {
+			return invalid("duplicate role %q", role.Name)
+		}
+		roles[role.Name] = true
+	}
+	for _, role := range l.Roles {
+		if role.RenamedFrom != "" && roles[role.RenamedFrom] {
+			return invalid("role %q is renamed from %q, which is also in the layout", role.Name, role.RenamedFrom)
+		}
+	}
+	seen := map[layoutChannelKey]bool{}
+	// The keys of the channels' previous names, which must not belong to other channels of the layout.
+	renamed := map[string]layoutChannelKey{}
+	var check func(channel LayoutChannel, parent string) error
+	check = func(channel LayoutChannel, parent string) error {
+		if channel.Name == "" {
+			return invalid("channel name is empty")
+		}
+		key := layoutChannelKey{parent, channel.Type, channel.Name}
+		if seen[key] {
+			if parent != "" {
+				return invalid("duplicate channel %q of type %d in category %q", channel.Name, channel.Type, parent)
+			}
+			return invalid("duplicate channel %q of type %d", channel.Name, channel.Type)
+		}
+		seen[key] = true
+		if channel.RenamedFrom != "" {
+			renamed[channel.Name] = layoutChannelKey{parent, channel.Type, channel.RenamedFrom}
+		}
+		if channel.Type == ChannelTypeGuildCategory && parent != "" {
+			return invalid("category %q is inside category %q", channel.Name, parent)
+		}
+		if channel.Type != ChannelTypeGuildCategory && len(channel.Channels) > 0 {
+			return invalid("channel %q is not a category but contains channels", channel.Name)
+		}
+		for _, overwrite := range channel.Overwrites {
+			if (overwrite.Role == "") == (overwrite.Member == 0) {
+				return invalid("overwrite on channel %q must have exactly one of role & member", channel.Name)
+			}
+		}
+		for _, child := range channel.Channels {
+			err := check(child, channel.Name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, channel := range l.Channels {
+		err := check(channel, "")
+		if err != nil {
+			return err
+		}
+	}
+	for name, key := range renamed {
+		if seen[key] {
+			return invalid("channel %q is renamed from %q, which is also in the layout", name, key.Name)
+		}
+	}
+	if ref := l.Settings.AfkChannel; ref != nil {
+		if _, ok := resolveLayoutChannelRef(seen, *ref, ChannelTypeGuildVoice); !ok {
+			return invalid("settings refer to unknown or ambiguous voice channel %q", *ref)
+		}
+	}
+	for _, ref := range []*string{l.Settings.SystemChannel, l.Settings.RulesChannel, l.Settings.PublicUpdatesChannel} {
+		if ref == nil {
+			continue
+		}
+		if _, ok := resolveLayoutChannelRef(seen, *ref, ChannelTypeGuildText); !ok {
+			return invalid("settings refer to unknown or ambiguous text channel %q", *ref)
+		}
+	}
+	return nil
+}
+
+// layoutChannelKey identifies a channel in a layout, as names are only unique among channels of the same type in the same category.
+// Parent is the name of the channel's category & is "" for categories & uncategorized channels.
+type layoutChannelKey struct {
+	Parent string
+	Type   int
+	Name   string
+}
+
+// resolveLayoutChannelRef returns the key of the channel of the passed type a settings reference refers to, see GuildLayoutSettings.
+// It returns false if no channel or more than one channel matches.
+func resolveLayoutChannelRef[V any](channels map[layoutChannelKey]V, ref string, channelType int) (layoutChannelKey, bool) {
+	uncategorized := layoutChannelKey{"", channelType, ref}
+	if _, ok := channels[uncategorized]; ok {
+		return uncategorized, true
+	}
+	var qualified, named []layoutChannelKey
+	for key := range channels {
+		if key.Type != channelType || key.Parent == "" {
+			continue
+		}
+		if key.Parent+"/"+key.Name == ref {
+			qualified = append(qualified, key)
+		}
+		if key.Name == ref {
+			named = append(named, key)
+		}
+	}
+	if len(qualified) == 1 {
+		return qualified[0], true
+	}
+	if len(qualified) == 0 && len(named) == 1 {
+		return named[0], true
+	}
+	return layoutChannelKey{}, false
+}
+
+// BuildGuildLayout builds the layout of the passed guild & its channels, as returned by FetchGuild & ListGuildChannels.
+//
+// Possible Errors:
+//   - ErrInvalidGuildLayout: Returned if roles share a name, or channels of the same type share a name in the same category, as a layout
+//     can't tell them apart.
+func BuildGuildLayout(guild *Guild, channels []Channel) (*GuildLayout, error) {
+	roleNames := layoutRoleNames(guild)
+	layout := &GuildLayout{
+		Settings: layoutSettings(guild, channels),
+		Roles:    []LayoutRole{},
+		Channels: []LayoutChannel{},
+	}
+	for _, role := range sortLayoutRoles(guild.Roles) {
+		if role.Managed {
+			continue
+		}
+		layout.Roles = append(layout.Roles, LayoutRole{
+			Name:        roleNames[role.ID],
+			Permissions: role.Permissions,
+			Color:       role.Color,
+			Hoist:       role.Hoist,
+			Mentionable: role.Mentionable,
+		})
+	}
+	sorted := sortLayoutChannels(channels)
+	children := map[Snowflake][]LayoutChannel{}
+	for _, channel := range sorted {
+		if channel.ParentID != nil && channel.Type != ChannelTypeGuildCategory {
+			children[*channel.ParentID] = append(children[*channel.ParentID], layoutChannel(channel, roleNames))
+		}
+	}
+	for _, channel := range sorted {
+		if channel.Type == ChannelTypeGuildCategory {
+			category := layoutChannel(channel, roleNames)
+			category.Channels = children[channel.ID]
+			layout.Channels = append(layout.Channels, category)
+		} else if channel.ParentID == nil {
+			layout.Channels = append(layout.Channels, layoutChannel(channel, roleNames))
+		}
+	}
+	err := layout.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("error building layout: %w", err)
+	}
+	return layout, nil
+}
+
+// layoutSettings returns the settings of the passed guild, referring to its channels as described by GuildLayoutSettings.
+func layoutSettings(guild *Guild, channels []Channel) GuildLayoutSettings {
+	keys := layoutChannelKeys(channels)
+	byKey := map[layoutChannelKey]Snowflake{}
+	for id, key := range keys {
+		byKey[key] = id
+	}
+	channelRef := func(id *Snowflake) *string {
+		if id == nil {
+			return nil
+		}
+		key, ok := keys[*id]
+		if !ok {
+			return nil
+		}
+		ref := key.Name
+		if resolved, ok := resolveLayoutChannelRef(byKey, ref, key.Type); !ok || resolved != key {
+			ref = key.Parent + "/" + key.Name
+		}
+		return &ref
+	}
+	return GuildLayoutSettings{
+		Name:                        guild.Name,
+		Description:                 guild.Description,
+		VerificationLevel:           guild.VerificationLevel,
+		DefaultMessageNotifications: guild.DefaultMessageNotifications,
+		ExplicitContentFilter:       guild.ExplicitContentFilter,
+		AfkTimeout:                  guild.AfkTimeout,
+		AfkChannel:                  channelRef(guild.AfkChannelID),
+		SystemChannel:               channelRef(guild.SystemChannelID),
+		RulesChannel:                channelRef(guild.RulesChannelID),
+		PublicUpdatesChannel:        channelRef(guild.PublicUpdatesChannelID),
+		PreferredLocale:             guild.PreferredLocale,
+	}
+}
+
+// layoutChannelKeys maps the IDs of the passed channels to their keys, the names of their categories as their parents.
+func layoutChannelKeys(channels []Channel) map[Snowflake]layoutChannelKey {
+	categories := map[Snowflake]string{}
+	for _, channel := range channels {
+		if channel.Type == ChannelTypeGuildCategory {
+			categories[channel.ID] = channel.Name
+		}
+	}
+	keys := map[Snowflake]layoutChannelKey{}
+	for _, channel := range channels {
+		key := layoutChannelKey{Type: channel.Type, Name: channel.Name}
+		if channel.ParentID != nil && channel.Type != ChannelTypeGuildCategory {
+			key.Parent = categories[*channel.ParentID]
+		}
+		keys[channel.ID] = key
+	}
+	return keys
+}
+
+// sortLayoutRoles returns a copy of the passed roles ordered from highest to lowest.
+func sortLayoutRoles(roles []Role) []Role {
+	sorted := append([]Role(nil), roles...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Position != sorted[j].Position {
+			return sorted[i].Position > sorted[j].Position
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+// sortLayoutChannels returns a copy of the passed channels ordered by position, categories after the other channels.
+func sortLayoutChannels(channels []Channel) []Channel {
+	sorted := append([]Channel(nil), channels...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if (a.Type == ChannelTypeGuildCategory) != (b.Type == ChannelTypeGuildCategory) {
+			return b.Type == ChannelTypeGuildCategory
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID < b.ID
+	})
+	return sorted
+}
+
+// orderLayoutChannels flattens the passed layout channels into the order Discord lists them: uncategorized channels, then categories
+// each followed by their channels, voice channels after the other channels of their category.
+func orderLayoutChannels(channels []LayoutChannel) []layoutChannelKey {
+	group := func(parent string, channels []LayoutChannel) []layoutChannelKey {
+		var others, voice []layoutChannelKey
+		for _, channel := range channels {
+			position := layoutChannelKey{parent, channel.Type, channel.Name}
+			if channel.Type == ChannelTypeGuildVoice || channel.Type == ChannelTypeGuildStageVoice {
+				voice = append(voice, position)
+			} else {
+				others = append(others, position)
+			}
+		}
+		return append(others, voice...)
+	}
+	var uncategorized []LayoutChannel
+	var categories []layoutChannelKey
+	for _, channel := range channels {
+		if channel.Type != ChannelTypeGuildCategory {
+			uncategorized = append(uncategorized, channel)
+			continue
+		}
+		categories = append(categories, layoutChannelKey{"", channel.Type, channel.Name})
+		categories = append(categories, group(channel.Name, channel.Channels)...)
+	}
+	return append(group("", uncategorized), categories...)
+}
+
+// layoutRoleNames maps the IDs of the guild's roles to the names used in layouts.
+func layoutRoleNames(guild *Guild) map[Snowflake]string {
+	names := map[Snowflake]string{}
+	for _, role := range guild.Roles {
+		names[role.ID] = role.Name
+	}
+	names[guild.ID] = EveryoneRoleName
+	return names
+}
+
+func layoutChannel(channel Channel, roleNames map[Snowflake]string) LayoutChannel {
+	layout := LayoutChannel{
+		Name:             channel.Name,
+		Type:             channel.Type,
+		NSFW:             channel.NSFW,
+		Bitrate:          channel.Bitrate,
+		UserLimit:        channel.UserLimit,
+		RateLimitPerUser: channel.RateLimitPerUser,
+	}
+	if channel.Topic != nil {
+		layout.Topic = *channel.Topic
+	}
+	for _, overwrite := range channel.PermissionOverwrites {
+		entry := LayoutOverwrite{Allow: overwrite.Allow, Deny: overwrite.Deny}
+		if overwrite.Type == OverwriteTypeMember {
+			entry.Member = overwrite.ID
+		} else if name, ok := roleNames[overwrite.ID]; ok {
+			entry.Role = name
+		} else {
+			entry.Role = overwrite.ID.String()
+		}
+		layout.Overwrites = append(layout.Overwrites, entry)
+	}
+	sortOverwrites(layout.Overwrites)
+	return layout
+}
+
+func sortOverwrites(overwrites []LayoutOverwrite) {
+	sort.SliceStable(overwrites, func(i, j int) bool {
+		if overwrites[i].Role != overwrites[j].Role {
+			return overwrites[i].Role < overwrites[j].Role
+		}
+		return overwrites[i].Member < overwrites[j].Member
+	})
+}
+
+func equalOverwrites(a []LayoutOverwrite, b []LayoutOverwrite) bool {
+	a = append([]LayoutOverwrite(nil), a...)
+	b = append([]LayoutOverwrite(nil), b...)
+	sortOverwrites(a)
+	sortOverwrites(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffGuildLayout compares the passed guild & its channels, as returned by FetchGuild & ListGuildChannels, to the desired layout
+// & returns the plan that makes the guild match it.
+//
+// Roles & channels of the guild missing from the layout are deleted, except managed roles. Roles & channels with a RenamedFrom
+// matching an existing role or channel are renamed. Channels are moved between categories rather than recreated when their category
+// changes. A positions change is added when the order of the guild's roles or channels differs from the layout's.
+//
+// Possible Errors:
+//   - ErrInvalidGuildLayout: Returned if the desired layout is invalid or an overwrite refers to an unknown role.
+func DiffGuildLayout(guild *Guild, channels []Channel, desired *GuildLayout) (*GuildLayoutPlan, error) {
+	err := desired.Validate()
+	if err != nil {
+		return nil, err
+	}
+	plan := &GuildLayoutPlan{Changes: []GuildLayoutChange{}}
+
+	// Roles
+	known := map[string]bool{EveryoneRoleName: true}
+	for _, role := range guild.Roles {
+		if role.Managed {
+			known[role.Name] = true
+		}
+	}
+	var liveRoles []Role
+	var everyone *Role
+	for i, role := range guild.Roles {
+		if role.ID == guild.ID {
+			everyone = &guild.Roles[i]
+		} else if !role.Managed {
+			liveRoles = append(liveRoles, role)
+		}
+	}
+	matchedRoles := map[Snowflake]string{}
+	findRole := func(name string) *Role {
+		for i, live := range liveRoles {
+			if live.Name == name {
+				if _, ok := matchedRoles[live.ID]; !ok {
+					return &liveRoles[i]
+				}
+			}
+		}
+		return nil
+	}
+	var createdRoles []string
+	for i, role := range desired.Roles {
+		known[role.Name] = true
+		var fields []string
+		var match *Role
+		if role.Name == EveryoneRoleName {
+			match = everyone
+		} else {
+			match = findRole(role.Name)
+			if match == nil && role.RenamedFrom != "" {
+				match = findRole(role.RenamedFrom)
+				if match != nil {
+					fields = append(fields, "name")
+				}
+			}
+		}
+		if match == nil {
+			plan.Changes = append(plan.Changes, GuildLayoutChange{Action: LayoutActionCreate, Kind: LayoutKindRole, Name: role.Name, Role: &desired.Roles[i]})
+			createdRoles = append(createdRoles, role.Name)
+			continue
+		}
+		matchedRoles[match.ID] = role.Name
+		if match.Permissions != role.Permissions {
+			fields = append(fields, "permissions")
+		}
+		if match.Color != role.Color {
+			fields = append(fields, "color")
+		}
+		if match.Hoist != role.Hoist {
+			fields = append(fields, "hoist")
+		}
+		if match.Mentionable != role.Mentionable {
+			fields = append(fields, "mentionable")
+		}
+		if len(fields) > 0 {
+			plan.Changes = append(plan.Changes, GuildLayoutChange{Action: LayoutActionUpdate, Kind: LayoutKindRole, ID: match.ID, Name: role.Name, Fields: fields, Role: &desired.Roles[i]})
+		}
+	}
+
+	// Channels, with overwrites compared using the names roles have once the plan is applied.
+	roleNames := layoutRoleNames(guild)
+	for id, name := range matchedRoles {
+		roleNames[id] = name
+	}
+	var liveCategories, liveChannels []Channel
+	for _, channel := range channels {
+		if channel.Type == ChannelTypeGuildCategory {
+			liveCategories = append(liveCategories, channel)
+		} else if channel.Type != ChannelTypeAnnouncementThread && channel.Type != ChannelTypePublicThread && channel.Type != ChannelTypePrivateThread {
+			liveChannels = append(liveChannels, channel)
+		}
+	}
+	// The names categories have once the plan is applied, so renaming a category doesn't move its channels.
+	categoryNames := map[Snowflake]string{}
+	for _, category := range liveCategories {
+		categoryNames[category.ID] = category.Name
+	}
+	matchedChannels := map[Snowflake]LayoutChannel{}
+	matchedParents := map[Snowflake]string{}
+	desiredChannels := map[layoutChannelKey]bool{}
+	for _, channel := range desired.Channels {
+		desiredChannels[layoutChannelKey{"", channel.Type, channel.Name}] = true
+		for _, child := range channel.Channels {
+			desiredChannels[layoutChannelKey{channel.Name, child.Type, child.Name}] = true
+		}
+	}
+	// findChannel prefers a channel in the passed category, so channels sharing a name in different categories are told apart. Channels
+	// in another category are only moved if the layout doesn't keep a channel with their name in that category.
+	findChannel := func(name string, channelType int, parent *string, candidates []Channel) *Channel {
+		key := layoutChannelKey{Type: channelType, Name: name}
+		if parent != nil {
+			key.Parent = *parent
+		}
+		var fallback *Channel
+		for i, live := range candidates {
+			if live.Name != name || live.Type != channelType {
+				continue
+			}
+			if _, ok := matchedChannels[live.ID]; ok {
+				continue
+			}
+			liveKey := layoutChannelKey{Type: live.Type, Name: live.Name}
+			if live.ParentID != nil {
+				liveKey.Parent = categoryNames[*live.ParentID]
+			}
+			if liveKey == key {
+				return &candidates[i]
+			}
+			if fallback == nil && !desiredChannels[liveKey] {
+				fallback = &candidates[i]
+			}
+		}
+		return fallback
+	}
+	var categoryChanges, channelChanges []GuildLayoutChange
+	createdChannels := map[string][]LayoutChannel{}
+	diffChannel := func(channel LayoutChannel, parent *string, candidates []Channel) error {
+		for _, overwrite := range channel.Overwrites {
+			if overwrite.Role != "" && !known[overwrite.Role] {
+				return fmt.Errorf("%w: overwrite on channel %q refers to unknown role %q", ErrInvalidGuildLayout, channel.Name, overwrite.Role)
+			}
+		}
+		wanted := channel
+		wanted.Channels = nil
+		change := GuildLayoutChange{Kind: LayoutKindChannel, Name: channel.Name, Parent: parent, Channel: &wanted}
+		match := findChannel(channel.Name, channel.Type, parent, candidates)
+		if match == nil && channel.RenamedFrom != "" {
+			match = findChannel(channel.RenamedFrom, channel.Type, parent, candidates)
+			if match != nil {
+				change.Fields = append(change.Fields, "name")
+			}
+		}
+		if match == nil {
+			change.Action = LayoutActionCreate
+			var group string
+			if parent != nil {
+				group = *parent
+			}
+			createdChannels[group] = append(createdChannels[group], LayoutChannel{Name: channel.Name, Type: channel.Type})
+		} else {
+			matchedChannels[match.ID] = LayoutChannel{Name: channel.Name, Type: channel.Type}
+			if parent != nil {
+				matchedParents[match.ID] = *parent
+			}
+			if channel.Type == ChannelTypeGuildCategory {
+				categoryNames[match.ID] = channel.Name
+			}
+			live := layoutChannel(*match, roleNames)
+			var liveParent *string
+			if match.ParentID != nil {
+				if name, ok := categoryNames[*match.ParentID]; ok {
+					liveParent = &name
+				}
+			}
+			if (liveParent == nil) != (parent == nil) || (parent != nil && *liveParent != *parent) {
+				change.Fields = append(change.Fields, "parent")
+			}
+			if live.Topic != channel.Topic {
+				change.Fields = append(change.Fields, "topic")
+			}
+			if live.NSFW != channel.NSFW {
+				change.Fields = append(change.Fields, "nsfw")
+			}
+			if channel.Bitrate != 0 && live.Bitrate != channel.Bitrate {
+				change.Fields = append(change.Fields, "bitrate")
+			}
+			if live.UserLimit != channel.UserLimit {
+				change.Fields = append(change.Fields, "user_limit")
+			}
+			if live.RateLimitPerUser != channel.RateLimitPerUser {
+				change.Fields = append(change.Fields, "rate_limit_per_user")
+			}
+			if !equalOverwrites(live.Overwrites, channel.Overwrites) {
+				change.Fields = append(change.Fields, "overwrites")
+			}
+			if len(change.Fields) == 0 {
+				return nil
+			}
+			change.Action = LayoutActionUpdate
+			change.ID = match.ID
+		}
+		if channel.Type == ChannelTypeGuildCategory {
+			categoryChanges = append(categoryChanges, change)
+		} else {
+			channelChanges = append(channelChanges, change)
+		}
+		return nil
+	}
+	// Categories are matched first so their channels are compared with the names the categories will have.
+	for _, channel := range desired.Channels {
+		if channel.Type == ChannelTypeGuildCategory {
+			err = diffChannel(channel, nil, liveCategories)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, channel := range desired.Channels {
+		if channel.Type != ChannelTypeGuildCategory {
+			err = diffChannel(channel, nil, liveChannels)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		parent := channel.Name
+		for _, child := range channel.Channels {
+			err = diffChannel(child, &parent, liveChannels)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	plan.Changes = append(plan.Changes, categoryChanges...)
+	plan.Changes = append(plan.Changes, channelChanges...)
+
+	// Positions, compared with the order the guild has once roles & channels are renamed & created.
+	order := &LayoutOrder{}
+	for _, role := range desired.Roles {
+		if role.Name != EveryoneRoleName {
+			order.Roles = append(order.Roles, role.Name)
+		}
+	}
+	var currentRoles []string
+	for _, role := range sortLayoutRoles(liveRoles) {
+		if name, ok := matchedRoles[role.ID]; ok {
+			currentRoles = append(currentRoles, name)
+		}
+	}
+	currentRoles = append(currentRoles, createdRoles...)
+	for _, channel := range desired.Channels {
+		entry := LayoutChannel{Name: channel.Name, Type: channel.Type}
+		for _, child := range channel.Channels {
+			entry.Channels = append(entry.Channels, LayoutChannel{Name: child.Name, Type: child.Type})
+		}
+		order.Channels = append(order.Channels, entry)
+	}
+	sortedChannels := sortLayoutChannels(append(append([]Channel(nil), liveChannels...), liveCategories...))
+	// Channels moved to another category are compared in their new category, as it's where the parent changes put them.
+	var currentChannels []LayoutChannel
+	children := map[string][]LayoutChannel{}
+	for _, channel := range sortedChannels {
+		entry, ok := matchedChannels[channel.ID]
+		if !ok {
+			continue
+		}
+		if parent, ok := matchedParents[channel.ID]; ok {
+			children[parent] = append(children[parent], entry)
+		} else {
+			currentChannels = append(currentChannels, entry)
+		}
+	}
+	currentChannels = append(currentChannels, createdChannels[""]...)
+	for i, channel := range currentChannels {
+		if channel.Type == ChannelTypeGuildCategory {
+			currentChannels[i].Channels = append(children[channel.Name], createdChannels[channel.Name]...)
+		}
+	}
+	var positionFields []string
+	if !slices.Equal(currentRoles, order.Roles) {
+		positionFields = append(positionFields, "roles")
+	}
+	if !slices.Equal(orderLayoutChannels(currentChannels), orderLayoutChannels(order.Channels)) {
+		positionFields = append(positionFields, "channels")
+	}
+	if len(positionFields) > 0 {
+		plan.Changes = append(plan.Changes, GuildLayoutChange{Action: LayoutActionUpdate, Kind: LayoutKindPositions, Fields: positionFields, Order: order})
+	}
+
+	// Settings
+	var fields []string
+	optionalEqual := func(a *string, b *string) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+	}
+	have, want := layoutSettings(guild, channels), desired.Settings
+	if have.Name != want.Name {
+		fields = append(fields, "name")
+	}
+	if !optionalEqual(have.Description, want.Description) {
+		fields = append(fields, "description")
+	}
+	if have.VerificationLevel != want.VerificationLevel {
+		fields = append(fields, "verification_level")
+	}
+	if have.DefaultMessageNotifications != want.DefaultMessageNotifications {
+		fields = append(fields, "default_message_notifications")
+	}
+	if have.ExplicitContentFilter != want.ExplicitContentFilter {
+		fields = append(fields, "explicit_content_filter")
+	}
+	if have.AfkTimeout != want.AfkTimeout {
+		fields = append(fields, "afk_timeout")
+	}
+	if !optionalEqual(have.AfkChannel, want.AfkChannel) {
+		fields = append(fields, "afk_channel")
+	}
+	if !optionalEqual(have.SystemChannel, want.SystemChannel) {
+		fields = append(fields, "system_channel")
+	}
+	if !optionalEqual(have.RulesChannel, want.RulesChannel) {
+		fields = append(fields, "rules_channel")
+	}
+	if !optionalEqual(have.PublicUpdatesChannel, want.PublicUpdatesChannel) {
+		fields = append(fields, "public_updates_channel")
+	}
+	if have.PreferredLocale != want.PreferredLocale {
+		fields = append(fields, "preferred_locale")
+	}
+	if len(fields) > 0 {
+		plan.Changes = append(plan.Changes, GuildLayoutChange{Action: LayoutActionUpdate, Kind: LayoutKindSettings, Name: want.Name, Fields: fields, Settings: &desired.Settings})
+	}
+
+	// Deletions, channels before the categories containing them & roles last.
+	for _, channel := range append(append([]Channel(nil), liveChannels...), liveCategories...) {
+		if _, ok := matchedChannels[channel.ID]; ok {
+			continue
+		}
+		change := GuildLayoutChange{Action: LayoutActionDelete, Kind: LayoutKindChannel, ID: channel.ID, Name: channel.Name}
+		if channel.ParentID != nil {
+			if name, ok := categoryNames[*channel.ParentID]; ok {
+				change.Parent = &name
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	for _, role := range liveRoles {
+		if _, ok := matchedRoles[role.ID]; !ok {
+			plan.Changes = append(plan.Changes, GuildLayoutChange{Action: LayoutActionDelete, Kind: LayoutKindRole, ID: role.ID, Name: role.Name})
+		}
+	}
+	return plan, nil
+}
+
+// ExportGuildLayout builds the layout of the guild with the passed guild ID. The guild is fetched from Discord's API rather than the
+// bot's State or Cache. See BuildGuildLayout.
+//
+// Possible Errors:
+//   - ErrInvalidGuildLayout: Returned if the guild's roles or channels share names a layout can't tell apart.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) ExportGuildLayout(guildID Snowflake) (*GuildLayout, error) {
+	guild, err := b.fetchGuild(guildID)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := b.ListGuildChannels(guildID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildGuildLayout(guild, channels)
+}
+
+// PlanGuildLayout returns the plan that makes the guild with the passed guild ID match the desired layout. See DiffGuildLayout.
+//
+// The guild is fetched from Discord's API rather than the bot's State or Cache, so the plan isn't made against out of date roles or
+// settings.
+//
+// Possible Errors:
+//   - ErrInvalidGuildLayout: Returned if the desired layout is invalid or an overwrite refers to an unknown role.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) PlanGuildLayout(guildID Snowflake, desired *GuildLayout) (*GuildLayoutPlan, error) {
+	guild, err := b.fetchGuild(guildID)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := b.ListGuildChannels(guildID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffGuildLayout(guild, channels, desired)
+}
+
+// ApplyGuildLayoutPlan applies the changes of the passed plan to the guild with the passed guild ID in order.
+//
+// Roles & channels created or renamed by the plan can be referred to by later changes. If a change fails, the changes before it remain
+// applied. Roles can only be moved below the bot's highest role.
+//
+// Possible Errors:
+//   - ErrInvalidGuildLayout: Returned if a change refers to a role or channel that does not exist.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permissions to manage the guild, roles & channels.
+func (b *Bot) ApplyGuildLayoutPlan(guildID Snowflake, plan *GuildLayoutPlan) error {
+	roles, err := b.ListGuildRoles(guildID)
+	if err != nil {
+		return err
+	}
+	channels, err := b.ListGuildChannels(guildID)
+	if err != nil {
+		return err
+	}
+	// Roles & channels deleted by the plan are left out so they aren't mistaken for the ones it keeps. Names still shared by several roles
+	// or channels are left out too rather than resolved to an arbitrary one.
+	deleted := map[Snowflake]bool{}
+	for _, change := range plan.Changes {
+		if change.Action == LayoutActionDelete {
+			deleted[change.ID] = true
+		}
+	}
+	roleIDs := map[string]Snowflake{}
+	sharedRoles := map[string]bool{}
+	for _, role := range roles {
+		if deleted[role.ID] || role.ID == guildID || sharedRoles[role.Name] {
+			continue
+		}
+		if _, ok := roleIDs[role.Name]; ok {
+			delete(roleIDs, role.Name)
+			sharedRoles[role.Name] = true
+			continue
+		}
+		roleIDs[role.Name] = role.ID
+	}
+	roleIDs[EveryoneRoleName] = guildID
+	channelIDs := map[layoutChannelKey]Snowflake{}
+	sharedChannels := map[layoutChannelKey]bool{}
+	for id, key := range layoutChannelKeys(channels) {
+		if deleted[id] || sharedChannels[key] {
+			continue
+		}
+		if _, ok := channelIDs[key]; ok {
+			delete(channelIDs, key)
+			sharedChannels[key] = true
+			continue
+		}
+		channelIDs[key] = id
+	}
+	for _, change := range plan.Changes {
+		err = b.applyGuildLayoutChange(guildID, change, roleIDs, channelIDs)
+		if err != nil {
+			return fmt.Errorf("error applying %q: %w", change.String(), err)
+		}
+	}
+	return nil
+}
+
+func (b *Bot) applyGuildLayoutChange(guildID Snowflake, change GuildLayoutChange, roleIDs map[string]Snowflake, channelIDs map[layoutChannelKey]Snowflake) error {
+	switch {
+	case change.Kind == LayoutKindRole && change.Action == LayoutActionCreate:
+		role, err := b.CreateGuildRole(guildID, CreateGuildRoleParams{
+			Name:        change.Role.Name,
+			Permissions: &change.Role.Permissions,
+			Color:       change.Role.Color,
+			Hoist:       change.Role.Hoist,
+			Mentionable: change.Role.Mentionable,
+		})
+		if err != nil {
+			return err
+		}
+		roleIDs[role.Name] = role.ID
+		return nil
+	case change.Kind == LayoutKindRole && change.Action == LayoutActionUpdate:
+		params := ModifyGuildRoleParams{
+			Permissions: &change.Role.Permissions,
+			Color:       &change.Role.Color,
+			Hoist:       &change.Role.Hoist,
+			Mentionable: &change.Role.Mentionable,
+		}
+		// @everyone can't be renamed.
+		if change.ID != guildID {
+			params.Name = &change.Role.Name
+		}
+		_, err := b.ModifyGuildRole(guildID, change.ID, params)
+		if err != nil {
+			return err
+		}
+		for name, id := range roleIDs {
+			if id == change.ID {
+				delete(roleIDs, name)
+			}
+		}
+		roleIDs[change.Role.Name] = change.ID
+		return nil
+	case change.Kind == LayoutKindRole && change.Action == LayoutActionDelete:
+		return b.DeleteGuildRole(guildID, change.ID)
+	case change.Kind == LayoutKindChannel && change.Action == LayoutActionDelete:
+		_, err := b.DeleteChannel(change.ID)
+		return err
+	case change.Kind == LayoutKindChannel:
+		overwrites, err := resolveLayoutOverwrites(change.Channel.Overwrites, roleIDs)
+		if err != nil {
+			return err
+		}
+		// A pointer to 0 moves the channel out of its category, see ModifyChannelParams.
+		parentID := new(Snowflake)
+		if change.Parent != nil {
+			id, ok := channelIDs[layoutChannelKey{"", ChannelTypeGuildCategory, *change.Parent}]
+			if !ok {
+				return fmt.Errorf("%w: unknown or ambiguous category %q", ErrInvalidGuildLayout, *change.Parent)
+			}
+			parentID = &id
+		}
+		key := layoutChannelKey{Type: change.Channel.Type, Name: change.Channel.Name}
+		if change.Parent != nil && change.Channel.Type != ChannelTypeGuildCategory {
+			key.Parent = *change.Parent
+		}
+		if change.Action == LayoutActionCreate {
+			channel, err := b.CreateGuildChannel(guildID, CreateGuildChannelParams{
+				Name:                 change.Channel.Name,
+				Type:                 change.Channel.Type,
+				Topic:                change.Channel.Topic,
+				NSFW:                 change.Channel.NSFW,
+				Bitrate:              change.Channel.Bitrate,
+				UserLimit:            change.Channel.UserLimit,
+				RateLimitPerUser:     change.Channel.RateLimitPerUser,
+				PermissionOverwrites: overwrites,
+				ParentID:             *parentID,
+			})
+			if err != nil {
+				return err
+			}
+			channelIDs[key] = channel.ID
+			return nil
+		}
+		var params ModifyChannelParams
+		for _, field := range change.Fields {
+			switch field {
+			case "name":
+				params.Name = &change.Channel.Name
+			case "parent":
+				params.ParentID = parentID
+			case "topic":
+				params.Topic = &change.Channel.Topic
+			case "nsfw":
+				params.NSFW = &change.Channel.NSFW
+			case "bitrate":
+				params.Bitrate = &change.Channel.Bitrate
+			case "user_limit":
+				params.UserLimit = &change.Channel.UserLimit
+			case "rate_limit_per_user":
+				params.RateLimitPerUser = &change.Channel.RateLimitPerUser
+			case "overwrites":
+				params.PermissionOverwrites = &overwrites
+			}
+		}
+		_, err = b.ModifyChannel(change.ID, params)
+		if err != nil {
+			return err
+		}
+		setLayoutChannelID(channelIDs, key, change.ID)
+		return nil
+	case change.Kind == LayoutKindPositions:
+		if slices.Contains(change.Fields, "roles") {
+			positions := make([]RolePosition, len(change.Order.Roles))
+			for i, name := range change.Order.Roles {
+				id, ok := roleIDs[name]
+				if !ok {
+					return fmt.Errorf("%w: unknown role %q", ErrInvalidGuildLayout, name)
+				}
+				// Positions count up from @everyone at 0, so the highest role gets the highest position.
+				position := len(change.Order.Roles) - i
+				positions[i] = RolePosition{ID: id, Position: &position}
+			}
+			_, err := b.ModifyGuildRolePositions(guildID, positions)
+			if err != nil {
+				return err
+			}
+		}
+		if slices.Contains(change.Fields, "channels") {
+			order := orderLayoutChannels(change.Order.Channels)
+			positions := make([]ChannelPosition, len(order))
+			for i, entry := range order {
+				id, ok := channelIDs[entry]
+				if !ok {
+					return fmt.Errorf("%w: unknown or ambiguous channel %q", ErrInvalidGuildLayout, entry.Name)
+				}
+				position := i
+				positions[i] = ChannelPosition{ID: id, Position: &position}
+			}
+			return b.ModifyGuildChannelPositions(guildID, positions)
+		}
+		return nil
+	case change.Kind == LayoutKindSettings:
+		settings := change.Settings
+		// A pointer to 0 clears a channel setting, see ModifyGuildParams.
+		channelID := func(name *string, channelType int) (*Snowflake, error) {
+			if name == nil {
+				return new(Snowflake), nil
+			}
+			key, ok := resolveLayoutChannelRef(channelIDs, *name, channelType)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown or ambiguous channel %q", ErrInvalidGuildLayout, *name)
+			}
+			id := channelIDs[key]
+			return &id, nil
+		}
+		var params ModifyGuildParams
+		for _, field := range change.Fields {
+			var err error
+			switch field {
+			case "name":
+				params.Name = &settings.Name
+			case "description":
+				params.Description = settings.Description
+				if params.Description == nil {
+					params.Description = new(string)
+				}
+			case "verification_level":
+				params.VerificationLevel = &settings.VerificationLevel
+			case "default_message_notifications":
+				params.DefaultMessageNotifications = &settings.DefaultMessageNotifications
+			case "explicit_content_filter":
+				params.ExplicitContentFilter = &settings.ExplicitContentFilter
+			case "afk_timeout":
+				params.AfkTimeout = &settings.AfkTimeout
+			case "afk_channel":
+				params.AfkChannelID, err = channelID(settings.AfkChannel, ChannelTypeGuildVoice)
+			case "system_channel":
+				params.SystemChannelID, err = channelID(settings.SystemChannel, ChannelTypeGuildText)
+			case "rules_channel":
+				params.RulesChannelID, err = channelID(settings.RulesChannel, ChannelTypeGuildText)
+			case "public_updates_channel":
+				params.PublicUpdatesChannelID, err = channelID(settings.PublicUpdatesChannel, ChannelTypeGuildText)
+			case "preferred_locale":
+				params.PreferredLocale = &settings.PreferredLocale
+			}
+			if err != nil {
+				return err
+			}
+		}
+		_, err := b.ModifyGuild(guildID, params)
+		return err
+	}
+	return fmt.Errorf("%w: unsupported change %q", ErrInvalidGuildLayout, change.String())
+}
+
+// setLayoutChannelID stores the passed channel ID under the passed key & drops the channel's previous key. The channels of a renamed
+// category are moved to its new name.
+func setLayoutChannelID(channelIDs map[layoutChannelKey]Snowflake, key layoutChannelKey, id Snowflake) {
+	var previous []layoutChannelKey
+	for existing, existingID := range channelIDs {
+		if existingID == id && existing != key {
+			previous = append(previous, existing)
+		}
+	}
+	for _, existing := range previous {
+		delete(channelIDs, existing)
+		if existing.Type != ChannelTypeGuildCategory || existing.Name == key.Name {
+			continue
+		}
+		moved := map[layoutChannelKey]Snowflake{}
+		for child, childID := range channelIDs {
+			if child.Parent == existing.Name {
+				delete(channelIDs, child)
+				child.Parent = key.Name
+				moved[child] = childID
+			}
+		}
+		for child, childID := range moved {
+			channelIDs[child] = childID
+		}
+	}
+	channelIDs[key] = id
+}
+
+func resolveLayoutOverwrites(overwrites []LayoutOverwrite, roleIDs map[string]Snowflake) ([]PermissionOverwrite, error) {
+	resolved := []PermissionOverwrite{}
+	for _, overwrite := range overwrites {
+		entry := PermissionOverwrite{Type: OverwriteTypeMember, ID: overwrite.Member, Allow: overwrite.Allow, Deny: overwrite.Deny}
+		if overwrite.Role != "" {
+			id, ok := roleIDs[overwrite.Role]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidGuildLayout, overwrite.Role)
+			}
+			entry.Type, entry.ID = OverwriteTypeRole, id
+		}
+		resolved = append(resolved, entry)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,241 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"time"
+)
+
+// Message flags
+const (
+	MessageFlagCrossposted           = 1 << 0
+	MessageFlagIsCrosspost           = 1 << 1
+	MessageFlagSuppressEmbeds        = 1 << 2
+	MessageFlagSourceMessageDeleted  = 1 << 3
+	MessageFlagUrgent                = 1 << 4
+	MessageFlagHasThread             = 1 << 5
+	MessageFlagEphemeral             = 1 << 6
+	MessageFlagLoading               = 1 << 7
+	MessageFlagSuppressNotifications = 1 << 12
+	MessageFlagIsComponentsV2        = 1 << 15
+)
+
+// Message represents a message object returned by Discord's API.
+type Message struct {
+	ID              Snowflake    `json:"id"`
+	ChannelID       Snowflake    `json:"channel_id"`
+	GuildID         Snowflake    `json:"guild_id"`
+	Author          MemberUser   `json:"author"`
+	Content         string       `json:"content"`
+	Timestamp       time.Time    `json:"timestamp"`
+	EditedTimestamp *time.Time   `json:"edited_timestamp"`
+	TTS             bool         `json:"tts"`
+	MentionEveryone bool         `json:"mention_everyone"`
+	Mentions        []MemberUser `json:"mentions"`
+	MentionRoles    []Snowflake  `json:"mention_roles"`
+	Attachments     []Attachment `json:"attachments"`
+	Embeds          []Embed      `json:"embeds"`
+	Pinned          bool         `json:"pinned"`
+	WebhookID       Snowflake    `json:"webhook_id"`
+	Type            int          `json:"type"`
+	ApplicationID   Snowflake    `json:"application_id"`
+	Flags           int          `json:"flags"`
+	Thread          *Channel     `json:"thread"`
+}
+
+// Attachment represents an attachment object returned by Discord's API.
+type Attachment struct {
+	ID          Snowflake `json:"id"`
+	Filename    string    `json:"filename"`
+	Description string    `json:"description,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int       `json:"size,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	ProxyURL    string    `json:"proxy_url,omitempty"`
+	Height      *int      `json:"height,omitempty"`
+	Width       *int      `json:"width,omitempty"`
+	Ephemeral   bool      `json:"ephemeral,omitempty"`
+}
+
+// Embed represents an embed object sent & returned by Discord's API.
+type Embed struct {
+	Title       string         `json:"title,omitempty"`
+	Type        string         `json:"type,omitempty"`
+	Description string         `json:"description,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Timestamp   *time.Time     `json:"timestamp,omitempty"`
+	Color       int            `json:"color,omitempty"`
+	Footer      *EmbedFooter   `json:"footer,omitempty"`
+	Image       *EmbedMedia    `json:"image,omitempty"`
+	Thumbnail   *EmbedMedia    `json:"thumbnail,omitempty"`
+	Video       *EmbedMedia    `json:"video,omitempty"`
+	Provider    *EmbedProvider `json:"provider,omitempty"`
+	Author      *EmbedAuthor   `json:"author,omitempty"`
+	Fields      []EmbedField   `json:"fields,omitempty"`
+}
+
+// EmbedFooter represents the footer of an embed.
+type EmbedFooter struct {
+	Text         string `json:"text"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
+}
+
+// EmbedMedia represents the image, thumbnail or video of an embed.
+type EmbedMedia struct {
+	URL      string `json:"url"`
+	ProxyURL string `json:"proxy_url,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Width    int    `json:"width,omitempty"`
+}
+
+// EmbedProvider represents the provider of an embed.
+type EmbedProvider struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// EmbedAuthor represents the author of an embed.
+type EmbedAuthor struct {
+	Name         string `json:"name"`
+	URL          string `json:"url,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
+}
+
+// EmbedField represents a field of an embed.
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// AllowedMentions controls which mentions in a message's content will ping.
+//
+// Parse can contain "roles", "users" & "everyone".
+type AllowedMentions struct {
+	Parse       []string    `json:"parse"`
+	Roles       []Snowflake `json:"roles,omitempty"`
+	Users       []Snowflake `json:"users,omitempty"`
+	RepliedUser bool        `json:"replied_user,omitempty"`
+}
+
+// File represents a file to be uploaded alongside a message.
+//
+// ContentType can be "" to send the file as application/octet-stream.
+type File struct {
+	Name        string
+	ContentType string
+	Description string
+	Reader      io.Reader
+}
+
+// newMessageRequest forms a request with the passed message payload as the body, see messagePayload & newPayloadRequest. PATCH requests
+// are treated as edits.
+func newMessageRequest(method string, url string, payload any, files []File) (*http.Request, error) {
+	payloadJSON, err := messagePayload(payload, files, method == http.MethodPatch)
+	if err != nil {
+		return nil, err
+	}
+	return newPayloadRequest(method, url, payloadJSON, files)
+}
+
+// messagePayload marshals the passed message payload. If files is not empty attachments referencing the files by their index are added
+// to the payload's attachments field, after the attachments already in it.
+//
+// An edit's attachments field lists every attachment the message keeps, so if edit is true & the payload has no attachments field the
+// field is left out. Discord then keeps the message's existing attachments & appends the files.
+func messagePayload(payload any, files []File, edit bool) ([]byte, error) {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	if len(files) == 0 {
+		return payloadJSON, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if payload != nil {
+		err = json.Unmarshal(payloadJSON, &fields)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshaling json: %w", err)
+		}
+	}
+	existing, ok := fields["attachments"]
+	if !ok && edit {
+		return payloadJSON, nil
+	}
+	var attachments []Attachment
+	if ok {
+		err = json.Unmarshal(existing, &attachments)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshaling json: %w", err)
+		}
+	}
+	for i, file := range files {
+		attachments = append(attachments, Attachment{ID: Snowflake(i), Filename: file.Name, Description: file.Description})
+	}
+	fields["attachments"], err = json.Marshal(attachments)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	payloadJSON, err = json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	return payloadJSON, nil
+}
+
+// newPayloadRequest forms a request with the passed JSON payload as the body.
+//
+// If files is empty the payload is sent as JSON, otherwise a multipart/form-data body is sent with the payload in the payload_json field
+// & the files in the files[n] fields.
+func newPayloadRequest(method string, url string, payloadJSON []byte, files []File) (*http.Request, error) {
+	if len(files) == 0 {
+		return http.NewRequest(method, url, bytes.NewReader(payloadJSON))
+	}
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="payload_json"`)
+	header.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("error creating payload part: %w", err)
+	}
+	_, err = part.Write(payloadJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error writing payload part: %w", err)
+	}
+	for i, file := range files {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[%d]"; filename=%q`, i, file.Name))
+		if file.ContentType != "" {
+			header.Set("Content-Type", file.ContentType)
+		} else {
+			header.Set("Content-Type", "application/octet-stream")
+		}
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("error creating file part: %w", err)
+		}
+		_, err = io.Copy(part, file.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("error writing file part: %w", err)
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error closing multipart writer: %w", err)
+	}
+	req, err := http.NewRequest(method, url, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req, nil
+}
@@ -6,55 +6,73 @@ import (
 	"net/url"
 	"strings"
 	"time"
-
-	"github.com/kodishim/discordapp/discordapp/util"
 )
 
 // AuthorizedUser represents the object of a user authorized to an application.
 type AuthorizedUser struct {
-	ID            string `json:"id"`
-	Username      string `json:"username"`
-	Discriminator string `json:"discriminator"`
-	Avatar        string `json:"avatar"`
-	Verified      bool   `json:"verified"`
-	Email         string `json:"email"`
-	Flags         int    `json:"flags"`
-	Banner        string `json:"banner"`
-	AccentColor   int    `json:"accent_color"`
-	PremiumType   int    `json:"premium_type"`
-	PublicFlags   int    `json:"public_flags"`
+	ID            Snowflake `json:"id"`
+	Username      string    `json:"username"`
+	Discriminator string    `json:"discriminator"`
+	Avatar        string    `json:"avatar"`
+	Verified      bool      `json:"verified"`
+	Email         string    `json:"email"`
+	Flags         int       `json:"flags"`
+	Banner        string    `json:"banner"`
+	AccentColor   int       `json:"accent_color"`
+	PremiumType   int       `json:"premium_type"`
+	PublicFlags   int       `json:"public_flags"`
 }
 
 // AuthInfo represents an authorization object returned by Discord's API.
 type AuthInfo struct {
 	Application struct {
-		ID                  string `json:"id"`
-		Name                string `json:"name"`
-		Icon                string `json:"icon"`
-		Description         string `json:"description"`
-		Hook                bool   `json:"hook"`
-		BotPublic           bool   `json:"bot_public"`
-		BotRequireCodeGrant bool   `json:"bot_require_code_grant"`
-		VerifyKey           string `json:"verify_key"`
+		ID                  Snowflake `json:"id"`
+		Name                string    `json:"name"`
+		Icon                string    `json:"icon"`
+		Description         string    `json:"description"`
+		Hook                bool      `json:"hook"`
+		BotPublic           bool      `json:"bot_public"`
+		BotRequireCodeGrant bool      `json:"bot_require_code_grant"`
+		VerifyKey           string    `json:"verify_key"`
 	} `json:"application"`
 	Scopes  []string  `json:"scopes"`
 	Expires time.Time `json:"expires"`
 	User    struct {
-		ID            string `json:"id"`
-		Username      string `json:"username"`
-		Avatar        string `json:"avatar"`
-		Discriminator string `json:"discriminator"`
-		GlobalName    string `json:"global_name"`
-		PublicFlags   int    `json:"public_flags"`
+		ID            Snowflake `json:"id"`
+		Username      string    `json:"username"`
+		Avatar        string    `json:"avatar"`
+		Discriminator string    `json:"discriminator"`
+		GlobalName    string    `json:"global_name"`
+		PublicFlags   int       `json:"public_flags"`
 	} `json:"user"`
 }
 
 // CreateAuthLink creates an authorization link. State can be "" for no state. Scope can be nil for no scopes.
 //
-// The redirectURI must be configured on the Discord application at https://discord.com/developers/applications.
+// The redirectURI must be configured on the Discord application at https://discord.com/developers/applications. The link always points
+// to Discord, even if the bot's BaseURL is set. No request is made, so the application ID must be known, e.g. by creating the
+// application with NewApplication or NewApplicationWithID; otherwise the link has no client ID. See LoadAuthLink.
 func (a *Application) CreateAuthLink(redirectURI string, state string, scopes []string) string {
+	return authLink(a.Bot.knownApplicationID(), redirectURI, state, scopes)
+}
+
+// LoadAuthLink creates an authorization link like CreateAuthLink, loading the application info first if the application ID isn't known.
+//
+// It returns the same errors as Bot.LoadApplication.
+func (a *Application) LoadAuthLink(redirectURI string, state string, scopes []string) (string, error) {
+	applicationID, err := a.Bot.applicationID()
+	if err != nil {
+		return "", err
+	}
+	return authLink(applicationID, redirectURI, state, scopes), nil
+}
+
+func authLink(applicationID Snowflake, redirectURI string, state string, scopes []string) string {
 	link := BaseDiscordAPIURL + "/oauth2/authorize"
-	link += "?client_id=" + a.Bot.Application.ID
+	link += "?client_id="
+	if applicationID != 0 {
+		link += applicationID.String()
+	}
 	if scopes != nil {
 		link += "&scope=" + strings.Join(scopes, "+")
 	}
@@ -72,13 +90,20 @@ func (a *Application) CreateAuthLink(redirectURI string, state string, scopes []
 //   - ErrInvalidAccessToken: Returned if the access token is invalid.
 //   - UnexpectedResponseError: Returned if an unexpected response was received.
 func FetchAuthInfo(accessToken string) (*AuthInfo, error) {
-	req, err := http.NewRequest(http.MethodGet, BaseDiscordAPIURL+"/oauth2/@me", nil)
+	return fetchAuthInfo(HTTPClient, BaseDiscordAPIURL, accessToken)
+}
+
+// fetchAuthInfo fetches the authorization info using the passed access token from the API at the passed base URL.
+//
+// It returns the same errors as FetchAuthInfo.
+func fetchAuthInfo(client *http.Client, baseURL string, accessToken string) (*AuthInfo, error) {
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/oauth2/@me", nil)
 	if err != nil {
 		return nil, fmt.Errorf("error forming request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	var authInfo AuthInfo
-	resp, err := util.MakeRequest(req, http.DefaultClient, &authInfo)
+	resp, err := makeRequest(req, client, baseURL, &authInfo)
 	if err != nil {
 		return nil, fmt.Errorf("error making request: %w", err)
 	}
@@ -103,7 +128,7 @@ func FetchAuthUser(accessToken string) (*AuthorizedUser, error) {
 	}
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	var user AuthorizedUser
-	resp, err := util.MakeRequest(req, http.DefaultClient, &user)
+	resp, err := makeRequest(req, HTTPClient, BaseDiscordAPIURL, &user)
 	if err != nil {
 		return nil, fmt.Errorf("error making request: %w", err)
 	}
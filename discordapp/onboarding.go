@@ -0,0 +1,187 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Onboarding modes
+const (
+	OnboardingModeDefault  = 0
+	OnboardingModeAdvanced = 1
+)
+
+// Onboarding prompt types
+const (
+	OnboardingPromptTypeMultipleChoice = 0
+	OnboardingPromptTypeDropdown       = 1
+)
+
+// WelcomeScreen represents a guild's welcome screen returned by Discord's API.
+type WelcomeScreen struct {
+	Description     *string                `json:"description"`
+	WelcomeChannels []WelcomeScreenChannel `json:"welcome_channels"`
+}
+
+// WelcomeScreenChannel represents a channel shown on a guild's welcome screen.
+//
+// EmojiID is set for custom emojis, EmojiName for unicode emojis.
+type WelcomeScreenChannel struct {
+	ChannelID   Snowflake  `json:"channel_id"`
+	Description string     `json:"description"`
+	EmojiID     *Snowflake `json:"emoji_id"`
+	EmojiName   *string    `json:"emoji_name"`
+}
+
+// ModifyWelcomeScreenParams represents the fields that can be changed on a guild's welcome screen. Nil fields are left unchanged.
+//
+// Reason is shown in the guild's audit log & can be "".
+type ModifyWelcomeScreenParams struct {
+	Enabled         *bool                   `json:"enabled,omitempty"`
+	WelcomeChannels *[]WelcomeScreenChannel `json:"welcome_channels,omitempty"`
+	Description     *string                 `json:"description,omitempty"`
+	Reason          string                  `json:"-"`
+}
+
+// Onboarding represents a guild's onboarding flow returned by Discord's API.
+type Onboarding struct {
+	GuildID           Snowflake          `json:"guild_id"`
+	Prompts           []OnboardingPrompt `json:"prompts"`
+	DefaultChannelIDs []Snowflake        `json:"default_channel_ids"`
+	Enabled           bool               `json:"enabled"`
+	Mode              int                `json:"mode"`
+}
+
+// OnboardingPrompt represents a question shown to new members during onboarding.
+type OnboardingPrompt struct {
+	ID           Snowflake                `json:"id"`
+	Type         int                      `json:"type"`
+	Options      []OnboardingPromptOption `json:"options"`
+	Title        string                   `json:"title"`
+	SingleSelect bool                     `json:"single_select"`
+	Required     bool                     `json:"required"`
+	InOnboarding bool                     `json:"in_onboarding"`
+}
+
+// OnboardingPromptOption represents an answer to an onboarding prompt, which gives members the channels & roles with the passed IDs.
+//
+// Discord returns the option's emoji as Emoji, but expects EmojiID, EmojiName & EmojiAnimated when modifying onboarding.
+type OnboardingPromptOption struct {
+	ID            Snowflake   `json:"id,omitempty"`
+	ChannelIDs    []Snowflake `json:"channel_ids"`
+	RoleIDs       []Snowflake `json:"role_ids"`
+	Emoji         *Emoji      `json:"emoji,omitempty"`
+	EmojiID       *Snowflake  `json:"emoji_id,omitempty"`
+	EmojiName     *string     `json:"emoji_name,omitempty"`
+	EmojiAnimated *bool       `json:"emoji_animated,omitempty"`
+	Title         string      `json:"title"`
+	Description   *string     `json:"description"`
+}
+
+// ModifyOnboardingParams represents the fields that can be changed on a guild's onboarding. Nil fields are left unchanged.
+//
+// Reason is shown in the guild's audit log & can be "".
+type ModifyOnboardingParams struct {
+	Prompts           *[]OnboardingPrompt `json:"prompts,omitempty"`
+	DefaultChannelIDs *[]Snowflake        `json:"default_channel_ids,omitempty"`
+	Enabled           *bool               `json:"enabled,omitempty"`
+	Mode              *int                `json:"mode,omitempty"`
+	Reason            string              `json:"-"`
+}
+
+// FetchWelcomeScreen fetches the welcome screen of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the welcome screen is disabled & the bot does not have the permission to manage the guild.
+func (b *Bot) FetchWelcomeScreen(guildID Snowflake) (*WelcomeScreen, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/welcome-screen", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var screen WelcomeScreen
+	err = b.guildRequest(req, &screen)
+	if err != nil {
+		return nil, err
+	}
+	return &screen, nil
+}
+
+// ModifyWelcomeScreen modifies the welcome screen of the guild with the passed guild ID & returns the updated welcome screen.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ModifyWelcomeScreen(guildID Snowflake, params ModifyWelcomeScreenParams) (*WelcomeScreen, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/welcome-screen", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	setAuditLogReason(req, params.Reason)
+	var screen WelcomeScreen
+	err = b.guildRequest(req, &screen)
+	if err != nil {
+		return nil, err
+	}
+	return &screen, nil
+}
+
+// FetchOnboarding fetches the onboarding prompts & default channels of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) FetchOnboarding(guildID Snowflake) (*Onboarding, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/onboarding", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var onboarding Onboarding
+	err = b.guildRequest(req, &onboarding)
+	if err != nil {
+		return nil, err
+	}
+	return &onboarding, nil
+}
+
+// ModifyOnboarding modifies the onboarding of the guild with the passed guild ID & returns the updated onboarding.
+//
+// Passed prompts replace all of the guild's existing prompts.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permissions to manage the guild & roles.
+func (b *Bot) ModifyOnboarding(guildID Snowflake, params ModifyOnboardingParams) (*Onboarding, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, b.apiURL()+"/guilds/"+guildID.String()+"/onboarding", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	setAuditLogReason(req, params.Reason)
+	var onboarding Onboarding
+	err = b.guildRequest(req, &onboarding)
+	if err != nil {
+		return nil, err
+	}
+	return &onboarding, nil
+}
@@ -0,0 +1,137 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GuildPruneResult represents the result of a guild prune.
+//
+// Pruned is nil if the prune was started without computing the prune count.
+type GuildPruneResult struct {
+	Pruned *int `json:"pruned"`
+}
+
+// BeginGuildPruneParams represents the options used when pruning a guild.
+//
+// Days is the number of days of inactivity after which members are pruned, between 1 & 30, 0 for Discord's default of 7.
+// By default members with roles are not pruned, IncludeRoles are the IDs of roles whose members should be pruned as well.
+// ComputePruneCount should be false for large guilds, in which case the number of pruned members is not returned.
+// Reason is shown in the guild's audit log & can be "".
+type BeginGuildPruneParams struct {
+	Days              int
+	IncludeRoles      []Snowflake
+	ComputePruneCount bool
+	Reason            string
+}
+
+func validatePruneDays(days int) error {
+	if days < 0 || days > 30 {
+		return fmt.Errorf("%w: %d", ErrInvalidPruneDays, days)
+	}
+	return nil
+}
+
+// GetGuildPruneCount returns the number of members that would be removed from the guild with the passed guild ID by a prune.
+//
+// Days & includeRoles have the same meaning as in BeginGuildPruneParams. Days can be 0 for Discord's default of 7.
+//
+// Possible Errors:
+//   - ErrInvalidPruneDays: Returned if days is not between 0 & 30.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to kick members.
+func (b *Bot) GetGuildPruneCount(guildID Snowflake, days int, includeRoles []Snowflake) (int, error) {
+	err := validatePruneDays(days)
+	if err != nil {
+		return 0, err
+	}
+	query := url.Values{}
+	if days > 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	if len(includeRoles) > 0 {
+		query.Set("include_roles", joinSnowflakes(includeRoles, ","))
+	}
+	link := b.apiURL() + "/guilds/" + guildID.String() + "/prune"
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error forming request: %w", err)
+	}
+	var result GuildPruneResult
+	resp, err := b.Request(req, &result)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return 0, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return 0, ErrMissingPermissions
+			}
+		}
+		return 0, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK || result.Pruned == nil {
+		return 0, &UnexpectedResponseError{resp}
+	}
+	return *result.Pruned, nil
+}
+
+// BeginGuildPrune removes inactive members from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrInvalidPruneDays: Returned if the params' days is not between 0 & 30.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to kick members.
+func (b *Bot) BeginGuildPrune(guildID Snowflake, params BeginGuildPruneParams) (*GuildPruneResult, error) {
+	err := validatePruneDays(params.Days)
+	if err != nil {
+		return nil, err
+	}
+	payload := struct {
+		Days              int         `json:"days,omitempty"`
+		ComputePruneCount bool        `json:"compute_prune_count"`
+		IncludeRoles      []Snowflake `json:"include_roles,omitempty"`
+	}{params.Days, params.ComputePruneCount, params.IncludeRoles}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/prune", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	setAuditLogReason(req, params.Reason)
+	var result GuildPruneResult
+	resp, err := b.Request(req, &result)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &result, nil
+}
@@ -0,0 +1,212 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CreateGuildRoleParams represents the options used when creating a guild role.
+//
+// Permissions defaults to the @everyone role's permissions when nil.
+type CreateGuildRoleParams struct {
+	Name        string       `json:"name,omitempty"`
+	Permissions *Permissions `json:"permissions,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Hoist       bool         `json:"hoist,omitempty"`
+	Mentionable bool         `json:"mentionable,omitempty"`
+}
+
+// ModifyGuildRoleParams represents the fields that can be changed on a guild role. Nil fields are left unchanged.
+type ModifyGuildRoleParams struct {
+	Name        *string      `json:"name,omitempty"`
+	Permissions *Permissions `json:"permissions,omitempty"`
+	Color       *int         `json:"color,omitempty"`
+	Hoist       *bool        `json:"hoist,omitempty"`
+	Mentionable *bool        `json:"mentionable,omitempty"`
+}
+
+// RolePosition represents the new position of a role when modifying guild role positions.
+type RolePosition struct {
+	ID       Snowflake `json:"id"`
+	Position *int      `json:"position,omitempty"`
+}
+
+// ListGuildRoles lists the roles of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) ListGuildRoles(guildID Snowflake) ([]Role, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/roles", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var roles []Role
+	err = b.guildRequest(req, &roles)
+	if err != nil {
+		return nil, err
+	}
+	if b.State != nil {
+		for _, role := range roles {
+			b.State.SetRole(guildID, role)
+		}
+	}
+	return roles, nil
+}
+
+// CreateGuildRole creates a role in the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage roles.
+func (b *Bot) CreateGuildRole(guildID Snowflake, params CreateGuildRoleParams) (*Role, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/roles", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	role, err := b.roleRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if b.State != nil {
+		b.State.SetRole(guildID, *role)
+	}
+	b.invalidateGuild(guildID)
+	return role, nil
+}
+
+// ModifyGuildRole modifies the role with the passed role ID in the guild with the passed guild ID & returns the updated role.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrRoleNotFound: Returned if the role does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage roles or the role is above the bot's highest role.
+func (b *Bot) ModifyGuildRole(guildID Snowflake, roleID Snowflake, params ModifyGuildRoleParams) (*Role, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/roles/"+roleID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	role, err := b.roleRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if b.State != nil {
+		b.State.SetRole(guildID, *role)
+	}
+	b.invalidateGuild(guildID)
+	return role, nil
+}
+
+// DeleteGuildRole deletes the role with the passed role ID from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrRoleNotFound: Returned if the role does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage roles or the role is above the bot's highest role.
+func (b *Bot) DeleteGuildRole(guildID Snowflake, roleID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/guilds/"+guildID.String()+"/roles/"+roleID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+			if discordErr.code == 10011 {
+				return ErrRoleNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	if b.State != nil {
+		b.State.RemoveRole(roleID)
+	}
+	b.invalidateGuild(guildID)
+	return nil
+}
+
+// ModifyGuildRolePositions modifies the positions of a set of roles in the guild with the passed guild ID & returns the guild's roles.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage roles or a role is above the bot's highest role.
+func (b *Bot) ModifyGuildRolePositions(guildID Snowflake, positions []RolePosition) ([]Role, error) {
+	body, err := json.Marshal(positions)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/roles", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var roles []Role
+	err = b.guildRequest(req, &roles)
+	if err != nil {
+		return nil, err
+	}
+	if b.State != nil {
+		for _, role := range roles {
+			b.State.SetRole(guildID, role)
+		}
+	}
+	b.invalidateGuild(guildID)
+	return roles, nil
+}
+
+func (b *Bot) roleRequest(req *http.Request) (*Role, error) {
+	var role Role
+	resp, err := b.Request(req, &role)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 10011 {
+				return nil, ErrRoleNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &role, nil
+}
@@ -0,0 +1,623 @@
+package discordapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HandlerFunc handles an interaction routed by a Router.
+type HandlerFunc func(ctx *InteractionContext) error
+
+// Middleware wraps a HandlerFunc, e.g. to log interactions or reject them before the handler runs.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// InteractionContext holds an interaction routed by a Router & the data parsed from it.
+//
+// Route is the command path such as "admin roles add" or the matched custom ID prefix. For components & modals CustomIDSuffix holds
+// the rest of the custom ID after the prefix.
+type InteractionContext struct {
+	Interaction    *Interaction
+	Client         *InteractionClient
+	Route          string
+	Command        *ApplicationCommandData
+	Component      *ComponentData
+	Modal          *ModalSubmitData
+	CustomIDSuffix string
+	options        []ApplicationCommandInteractionDataOption
+	// handled is set once the route's handler runs, so middleware can tell it apart from middleware that rejected the interaction.
+	handled bool
+}
+
+// Router routes interactions to handlers by command path & custom ID prefix, & generates the definitions of its commands.
+//
+// Routes should be registered before interactions are handled. Registering an invalid route panics, like http.ServeMux. BaseURL is used
+// as the BaseURL of the interaction clients the router creates.
+type Router struct {
+	BaseURL      string
+	commands     []*ApplicationCommand
+	handlers     map[string]HandlerFunc
+	autocomplete map[string]HandlerFunc
+	components   []prefixRoute
+	modals       []prefixRoute
+	middleware   []Middleware
+}
+
+// CommandGroup registers subcommands under a command or subcommand group.
+type CommandGroup struct {
+	router  *Router
+	command *ApplicationCommand
+	// group is the name of the subcommand group or "" for subcommands directly under the command.
+	group string
+}
+
+type prefixRoute struct {
+	prefix  string
+	handler HandlerFunc
+}
+
+// NewRouter creates & returns a pointer to an empty router.
+func NewRouter() *Router {
+	return &Router{handlers: map[string]HandlerFunc{}, autocomplete: map[string]HandlerFunc{}}
+}
+
+// Use adds middleware that wraps every handler of the router. Middleware runs in the order it was added.
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+func commandKey(commandType int, path string) string {
+	if commandType == 0 {
+		commandType = ApplicationCommandTypeChatInput
+	}
+	return strconv.Itoa(commandType) + " " + path
+}
+
+func (r *Router) addCommand(command *ApplicationCommand) {
+	for _, existing := range r.commands {
+		if existing.Name == command.Name && existing.Type == command.Type {
+			panic("discordapp: command " + command.Name + " registered twice")
+		}
+	}
+	r.commands = append(r.commands, command)
+}
+
+// Command registers a chat input command with the passed handler & returns its definition, which can be changed before calling Commands.
+//
+// Options should be a struct, or nil for no options, whose fields are turned into options as described in CommandOptions.
+func (r *Router) Command(name string, description string, options any, handler HandlerFunc) *ApplicationCommand {
+	commandOptions, err := CommandOptions(options)
+	if err != nil {
+		panic("discordapp: command " + name + ": " + err.Error())
+	}
+	command := &ApplicationCommand{Type: ApplicationCommandTypeChatInput, Name: name, Description: description, Options: commandOptions}
+	r.addCommand(command)
+	r.handlers[commandKey(command.Type, name)] = handler
+	return command
+}
+
+// Group registers a chat input command with subcommands.
+func (r *Router) Group(name string, description string) *CommandGroup {
+	command := &ApplicationCommand{Type: ApplicationCommandTypeChatInput, Name: name, Description: description}
+	r.addCommand(command)
+	return &CommandGroup{router: r, command: command}
+}
+
+// UserCommand registers a command shown in the context menu of users.
+func (r *Router) UserCommand(name string, handler HandlerFunc) *ApplicationCommand {
+	command := &ApplicationCommand{Type: ApplicationCommandTypeUser, Name: name}
+	r.addCommand(command)
+	r.handlers[commandKey(command.Type, name)] = handler
+	return command
+}
+
+// MessageCommand registers a command shown in the context menu of messages.
+func (r *Router) MessageCommand(name string, handler HandlerFunc) *ApplicationCommand {
+	command := &ApplicationCommand{Type: ApplicationCommandTypeMessage, Name: name}
+	r.addCommand(command)
+	r.handlers[commandKey(command.Type, name)] = handler
+	return command
+}
+
+// Autocomplete registers the handler of autocomplete interactions for the command with the passed path, such as "admin roles add".
+//
+// The option being completed is returned by InteractionContext.Focused.
+func (r *Router) Autocomplete(path string, handler HandlerFunc) {
+	r.autocomplete[path] = handler
+}
+
+// Component registers the handler of message components whose custom ID starts with the passed prefix. The longest matching prefix wins.
+func (r *Router) Component(prefix string, handler HandlerFunc) {
+	r.components = addPrefixRoute(r.components, prefix, handler)
+}
+
+// Modal registers the handler of modals whose custom ID starts with the passed prefix. The longest matching prefix wins.
+func (r *Router) Modal(prefix string, handler HandlerFunc) {
+	r.modals = addPrefixRoute(r.modals, prefix, handler)
+}
+
+func addPrefixRoute(routes []prefixRoute, prefix string, handler HandlerFunc) []prefixRoute {
+	for _, route := range routes {
+		if route.prefix == prefix {
+			panic("discordapp: custom id prefix " + strconv.Quote(prefix) + " registered twice")
+		}
+	}
+	routes = append(routes, prefixRoute{prefix, handler})
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].prefix) > len(routes[j].prefix)
+	})
+	return routes
+}
+
+func matchPrefix(routes []prefixRoute, customID string) (prefixRoute, bool) {
+	for _, route := range routes {
+		if strings.HasPrefix(customID, route.prefix) {
+			return route, true
+		}
+	}
+	return prefixRoute{}, false
+}
+
+// Definition returns the definition of the command the group belongs to, which can be changed before calling Commands.
+func (g *CommandGroup) Definition() *ApplicationCommand {
+	return g.command
+}
+
+// Group registers a subcommand group. Subcommand groups can't be nested.
+func (g *CommandGroup) Group(name string, description string) *CommandGroup {
+	if g.group != "" {
+		panic("discordapp: subcommand group " + g.command.Name + " " + g.group + " can't contain groups")
+	}
+	g.addOption(ApplicationCommandOption{Type: ApplicationCommandOptionTypeSubCommandGroup, Name: name, Description: description})
+	return &CommandGroup{router: g.router, command: g.command, group: name}
+}
+
+// Command registers a subcommand with the passed handler. Options are handled like in Router.Command.
+func (g *CommandGroup) Command(name string, description string, options any, handler HandlerFunc) {
+	path := g.command.Name + " " + name
+	if g.group != "" {
+		path = g.command.Name + " " + g.group + " " + name
+	}
+	commandOptions, err := CommandOptions(options)
+	if err != nil {
+		panic("discordapp: command " + path + ": " + err.Error())
+	}
+	g.addOption(ApplicationCommandOption{Type: ApplicationCommandOptionTypeSubCommand, Name: name, Description: description, Options: commandOptions})
+	g.router.handlers[commandKey(ApplicationCommandTypeChatInput, path)] = handler
+}
+
+// addOption adds the passed subcommand or group to the group's options.
+func (g *CommandGroup) addOption(option ApplicationCommandOption) {
+	options := &g.command.Options
+	if g.group != "" {
+		for i := range g.command.Options {
+			if g.command.Options[i].Name == g.group {
+				options = &g.command.Options[i].Options
+			}
+		}
+	}
+	for _, existing := range *options {
+		if existing.Name == option.Name {
+			panic("discordapp: subcommand " + g.command.Name + " " + option.Name + " registered twice")
+		}
+	}
+	*options = append(*options, option)
+}
+
+// Commands returns the definitions of the router's commands, to be registered with Bot.BulkOverwriteCommands.
+func (r *Router) Commands() []ApplicationCommand {
+	commands := make([]ApplicationCommand, len(r.commands))
+	for i, command := range r.commands {
+		commands[i] = *command
+	}
+	return commands
+}
+
+// Handle routes the interaction to its handler through the router's middleware.
+//
+// Possible Errors:
+//   - ErrNoInteractionHandler: Returned if no handler matches the interaction. Ping interactions are never routed.
+func (r *Router) Handle(interaction *Interaction) error {
+	ctx := &InteractionContext{Interaction: interaction, Client: interaction.Client()}
+	ctx.Client.BaseURL = r.BaseURL
+	var handler HandlerFunc
+	switch interaction.Type {
+	case InteractionTypeApplicationCommand, InteractionTypeApplicationCommandAutocomplete:
+		data, err := interaction.CommandData()
+		if err != nil {
+			return err
+		}
+		ctx.Command = data
+		ctx.Route = data.Name
+		ctx.options = data.Options
+		for len(ctx.options) == 1 && (ctx.options[0].Type == ApplicationCommandOptionTypeSubCommand || ctx.options[0].Type == ApplicationCommandOptionTypeSubCommandGroup) {
+			ctx.Route += " " + ctx.options[0].Name
+			ctx.options = ctx.options[0].Options
+		}
+		if interaction.Type == InteractionTypeApplicationCommandAutocomplete {
+			handler = r.autocomplete[ctx.Route]
+		} else {
+			handler = r.handlers[commandKey(data.Type, ctx.Route)]
+		}
+	case InteractionTypeMessageComponent:
+		data, err := interaction.ComponentData()
+		if err != nil {
+			return err
+		}
+		ctx.Component = data
+		route, ok := matchPrefix(r.components, data.CustomID)
+		if ok {
+			handler = route.handler
+			ctx.Route = route.prefix
+			ctx.CustomIDSuffix = strings.TrimPrefix(data.CustomID, route.prefix)
+		}
+	case InteractionTypeModalSubmit:
+		data, err := interaction.ModalSubmitData()
+		if err != nil {
+			return err
+		}
+		ctx.Modal = data
+		route, ok := matchPrefix(r.modals, data.CustomID)
+		if ok {
+			handler = route.handler
+			ctx.Route = route.prefix
+			ctx.CustomIDSuffix = strings.TrimPrefix(data.CustomID, route.prefix)
+		}
+	}
+	if handler == nil {
+		return fmt.Errorf("%w: interaction type %d %q", ErrNoInteractionHandler, interaction.Type, ctx.Route)
+	}
+	route := handler
+	handler = func(ctx *InteractionContext) error {
+		ctx.handled = true
+		return route(ctx)
+	}
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+	return handler(ctx)
+}
+
+// Option returns the option with the passed name the user filled in or nil if it was left empty.
+func (c *InteractionContext) Option(name string) *ApplicationCommandInteractionDataOption {
+	for i := range c.options {
+		if c.options[i].Name == name {
+			return &c.options[i]
+		}
+	}
+	return nil
+}
+
+// Focused returns the option being autocompleted or nil. Its Value is the user's partial input as a string regardless of the option's type.
+func (c *InteractionContext) Focused() *ApplicationCommandInteractionDataOption {
+	for i := range c.options {
+		if c.options[i].Focused {
+			return &c.options[i]
+		}
+	}
+	return nil
+}
+
+var (
+	snowflakeType  = reflect.TypeOf(Snowflake(0))
+	userType       = reflect.TypeOf(MemberUser{})
+	memberType     = reflect.TypeOf(Member{})
+	channelType    = reflect.TypeOf(Channel{})
+	roleType       = reflect.TypeOf(Role{})
+	attachmentType = reflect.TypeOf(Attachment{})
+)
+
+// optionType returns the option type of a field of the passed type or 0 if the type can't be bound.
+func optionType(t reflect.Type) int {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case snowflakeType:
+		return ApplicationCommandOptionTypeMentionable
+	case userType, memberType:
+		return ApplicationCommandOptionTypeUser
+	case channelType:
+		return ApplicationCommandOptionTypeChannel
+	case roleType:
+		return ApplicationCommandOptionTypeRole
+	case attachmentType:
+		return ApplicationCommandOptionTypeAttachment
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return ApplicationCommandOptionTypeString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ApplicationCommandOptionTypeInteger
+	case reflect.Float32, reflect.Float64:
+		return ApplicationCommandOptionTypeNumber
+	case reflect.Bool:
+		return ApplicationCommandOptionTypeBoolean
+	}
+	return 0
+}
+
+// optionField is a struct field bound to a command option.
+type optionField struct {
+	index    int
+	name     string
+	required bool
+}
+
+// optionFields returns the fields of the passed struct type that are bound to options.
+func optionFields(t reflect.Type) []optionField {
+	var fields []optionField
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("discord")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		field := optionField{index: i, name: parts[0]}
+		for _, flag := range parts[1:] {
+			if flag == "required" {
+				field.required = true
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// CommandOptions returns the option definitions of the passed struct, which InteractionContext.Bind can later fill in.
+//
+// Fields are bound to options by their discord tag, e.g. `discord:"user,required"`, which holds the option's name followed by the
+// flags required & autocomplete. The description tag sets the option's description, the min & max tags its minimum & maximum value
+// or length & the channel_types tag a comma separated list of allowed channel types.
+//
+// The option's type comes from the field's type: strings, integers, floats, bools, MemberUser or Member for users, Channel, Role,
+// Attachment & Snowflake for mentionables. Fields can be pointers to tell empty options apart from zero values.
+func CommandOptions(options any) ([]ApplicationCommandOption, error) {
+	if options == nil {
+		return nil, nil
+	}
+	t := reflect.TypeOf(options)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: options must be a struct, got %s", ErrInvalidCommandOption, t)
+	}
+	var definitions []ApplicationCommandOption
+	for _, field := range optionFields(t) {
+		structField := t.Field(field.index)
+		option := ApplicationCommandOption{
+			Type:        optionType(structField.Type),
+			Name:        field.name,
+			Description: structField.Tag.Get("description"),
+			Required:    field.required,
+		}
+		if option.Type == 0 {
+			return nil, fmt.Errorf("%w: field %s has unsupported type %s", ErrInvalidCommandOption, structField.Name, structField.Type)
+		}
+		if option.Description == "" {
+			option.Description = option.Name
+		}
+		option.Autocomplete = strings.Contains(structField.Tag.Get("discord")+",", ",autocomplete,")
+		for _, bound := range []string{"min", "max"} {
+			value := structField.Tag.Get(bound)
+			if value == "" {
+				continue
+			}
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: field %s has invalid %s %q", ErrInvalidCommandOption, structField.Name, bound, value)
+			}
+			switch {
+			case option.Type == ApplicationCommandOptionTypeString && bound == "min":
+				length := int(n)
+				option.MinLength = &length
+			case option.Type == ApplicationCommandOptionTypeString:
+				length := int(n)
+				option.MaxLength = &length
+			case bound == "min":
+				option.MinValue = &n
+			default:
+				option.MaxValue = &n
+			}
+		}
+		if channelTypes := structField.Tag.Get("channel_types"); channelTypes != "" {
+			for _, value := range strings.Split(channelTypes, ",") {
+				channelType, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil {
+					return nil, fmt.Errorf("%w: field %s has invalid channel type %q", ErrInvalidCommandOption, structField.Name, value)
+				}
+				option.ChannelTypes = append(option.ChannelTypes, channelType)
+			}
+		}
+		definitions = append(definitions, option)
+	}
+	// Discord requires required options to come before optional ones.
+	sort.SliceStable(definitions, func(i, j int) bool {
+		return definitions[i].Required && !definitions[j].Required
+	})
+	return definitions, nil
+}
+
+// Bind fills the fields of the struct dst points to with the options the user filled in, as described in CommandOptions.
+//
+// Possible Errors:
+//   - ErrInvalidCommandOption: Returned if dst isn't a pointer to a struct, a required option is missing or an option can't be bound.
+func (c *InteractionContext) Bind(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: bind destination must be a pointer to a struct", ErrInvalidCommandOption)
+	}
+	v = v.Elem()
+	var resolved ResolvedData
+	if c.Command != nil {
+		resolved = c.Command.Resolved
+	}
+	for _, field := range optionFields(v.Type()) {
+		option := c.Option(field.name)
+		if option == nil {
+			if field.required && c.Interaction.Type != InteractionTypeApplicationCommandAutocomplete {
+				return fmt.Errorf("%w: option %s is missing", ErrInvalidCommandOption, field.name)
+			}
+			continue
+		}
+		err := bindOption(v.Field(field.index), option, resolved)
+		if err != nil {
+			// The focused option holds partial input which may not parse as its type.
+			if option.Focused {
+				continue
+			}
+			return fmt.Errorf("%w: option %s: %s", ErrInvalidCommandOption, field.name, err)
+		}
+	}
+	return nil
+}
+
+func bindOption(field reflect.Value, option *ApplicationCommandInteractionDataOption, resolved ResolvedData) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		err := bindOption(value.Elem(), option, resolved)
+		if err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+	switch field.Type() {
+	case snowflakeType, userType, memberType, channelType, roleType, attachmentType:
+		var id Snowflake
+		err := json.Unmarshal(option.Value, &id)
+		if err != nil {
+			return err
+		}
+		var value any
+		var ok bool
+		switch field.Type() {
+		case snowflakeType:
+			value, ok = id, true
+		case userType:
+			value, ok = resolved.Users[id]
+		case memberType:
+			var member Member
+			member, ok = resolved.Members[id]
+			member.User = resolved.Users[id]
+			value = member
+		case channelType:
+			value, ok = resolved.Channels[id]
+		case roleType:
+			value, ok = resolved.Roles[id]
+		case attachmentType:
+			value, ok = resolved.Attachments[id]
+		}
+		if !ok {
+			return fmt.Errorf("%s is not in the resolved data", id)
+		}
+		field.Set(reflect.ValueOf(value))
+		return nil
+	}
+	return json.Unmarshal(option.Value, field.Addr().Interface())
+}
+
+// LogInteractions returns middleware that logs the route, user, duration & error of every interaction. Logger can be nil to use log.Default.
+func LogInteractions(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *InteractionContext) error {
+			start := time.Now()
+			err := next(ctx)
+			var userID Snowflake
+			if user := ctx.Interaction.Invoker(); user != nil {
+				userID = user.ID
+			}
+			if err != nil {
+				logger.Printf("interaction %q by %s failed after %s: %s", ctx.Route, userID, time.Since(start), err)
+			} else {
+				logger.Printf("interaction %q by %s handled in %s", ctx.Route, userID, time.Since(start))
+			}
+			return err
+		}
+	}
+}
+
+// RequirePermissions returns middleware that responds with an ephemeral message instead of running the handler if the user lacks the passed
+// permissions in the interaction's channel. Interactions in DMs are always rejected & autocomplete interactions are always let through.
+func RequirePermissions(permissions Permissions, message string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *InteractionContext) error {
+			if ctx.Interaction.Type == InteractionTypeApplicationCommandAutocomplete {
+				return next(ctx)
+			}
+			if ctx.Interaction.Member == nil || !ctx.Interaction.Member.Permissions.Has(permissions) {
+				return ctx.Client.RespondMessage(InteractionMessageParams{Content: message, Ephemeral: true})
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// Cooldown returns middleware that lets each user run each route once per duration, responding with an ephemeral message while they are
+// on cooldown. Autocomplete interactions are always let through.
+//
+// A use is only recorded once the route's handler returns without an error, so interactions rejected by later middleware or failing in
+// the handler don't start a cooldown.
+func Cooldown(duration time.Duration, message string) Middleware {
+	var mu sync.Mutex
+	lastUsed := map[string]time.Time{}
+	var nextPrune time.Time
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *InteractionContext) error {
+			user := ctx.Interaction.Invoker()
+			if ctx.Interaction.Type == InteractionTypeApplicationCommandAutocomplete || user == nil {
+				return next(ctx)
+			}
+			key := user.ID.String() + " " + ctx.Route
+			mu.Lock()
+			used, ok := lastUsed[key]
+			onCooldown := ok && time.Since(used) < duration
+			mu.Unlock()
+			if onCooldown {
+				return ctx.Client.RespondMessage(InteractionMessageParams{Content: message, Ephemeral: true})
+			}
+			err := next(ctx)
+			if err != nil || !ctx.handled {
+				return err
+			}
+			now := time.Now()
+			mu.Lock()
+			lastUsed[key] = now
+			// Expired uses are pruned at most once per duration rather than on every interaction.
+			if now.After(nextPrune) {
+				for k, t := range lastUsed {
+					if now.Sub(t) >= duration {
+						delete(lastUsed, k)
+					}
+				}
+				nextPrune = now.Add(duration)
+			}
+			mu.Unlock()
+			return nil
+		}
+	}
+}
+
+// Recover returns middleware that turns a panic in the handler into an error.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *InteractionContext) (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					err = fmt.Errorf("panic handling interaction %q: %v", ctx.Route, p)
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp/util"
+)
+
+// Scheduled event entity types
+const (
+	ScheduledEventEntityTypeStageInstance = 1
+	ScheduledEventEntityTypeVoice         = 2
+	ScheduledEventEntityTypeExternal      = 3
+)
+
+// Scheduled event statuses
+const (
+	ScheduledEventStatusScheduled = 1
+	ScheduledEventStatusActive    = 2
+	ScheduledEventStatusCompleted = 3
+	ScheduledEventStatusCanceled  = 4
+)
+
+// Scheduled event privacy levels
+const (
+	ScheduledEventPrivacyLevelGuildOnly = 2
+)
+
+// Recurrence rule frequencies
+const (
+	RecurrenceFrequencyYearly  = 0
+	RecurrenceFrequencyMonthly = 1
+	RecurrenceFrequencyWeekly  = 2
+	RecurrenceFrequencyDaily   = 3
+)
+
+// Recurrence rule weekdays
+const (
+	RecurrenceWeekdayMonday    = 0
+	RecurrenceWeekdayTuesday   = 1
+	RecurrenceWeekdayWednesday = 2
+	RecurrenceWeekdayThursday  = 3
+	RecurrenceWeekdayFriday    = 4
+	RecurrenceWeekdaySaturday  = 5
+	RecurrenceWeekdaySunday    = 6
+)
+
+// ScheduledEvent represents a guild scheduled event object returned by Discord's API.
+//
+// UserCount is only populated when fetched with the user count.
+type ScheduledEvent struct {
+	ID                 Snowflake                     `json:"id"`
+	GuildID            Snowflake                     `json:"guild_id"`
+	ChannelID          *Snowflake                    `json:"channel_id"`
+	CreatorID          *Snowflake                    `json:"creator_id"`
+	Name               string                        `json:"name"`
+	Description        *string                       `json:"description"`
+	ScheduledStartTime time.Time                     `json:"scheduled_start_time"`
+	ScheduledEndTime   *time.Time                    `json:"scheduled_end_time"`
+	PrivacyLevel       int                           `json:"privacy_level"`
+	Status             int                           `json:"status"`
+	EntityType         int                           `json:"entity_type"`
+	EntityID           *Snowflake                    `json:"entity_id"`
+	EntityMetadata     *ScheduledEventEntityMetadata `json:"entity_metadata"`
+	Creator            *MemberUser                   `json:"creator"`
+	UserCount          int                           `json:"user_count"`
+	Image              *string                       `json:"image"`
+	RecurrenceRule     *RecurrenceRule               `json:"recurrence_rule"`
+}
+
+// ScheduledEventEntityMetadata represents the additional metadata of a scheduled event. Location is required for external events.
+type ScheduledEventEntityMetadata struct {
+	Location string `json:"location,omitempty"`
+}
+
+// RecurrenceRule represents how often a scheduled event repeats.
+//
+// Discord only supports a subset of rules: daily (optionally restricted with ByWeekday), weekly & every other week with one ByWeekday,
+// monthly with one ByNWeekday & yearly with one ByMonth & one ByMonthDay.
+type RecurrenceRule struct {
+	Start      time.Time  `json:"start"`
+	End        *time.Time `json:"end,omitempty"`
+	Frequency  int        `json:"frequency"`
+	Interval   int        `json:"interval"`
+	ByWeekday  []int      `json:"by_weekday,omitempty"`
+	ByNWeekday []NWeekday `json:"by_n_weekday,omitempty"`
+	ByMonth    []int      `json:"by_month,omitempty"`
+	ByMonthDay []int      `json:"by_month_day,omitempty"`
+	ByYearDay  []int      `json:"by_year_day,omitempty"`
+	Count      *int       `json:"count,omitempty"`
+}
+
+// NWeekday represents a specific weekday within a month, for example the second Tuesday when N is 2 & Day is RecurrenceWeekdayTuesday.
+type NWeekday struct {
+	N   int `json:"n"`
+	Day int `json:"day"`
+}
+
+// ScheduledEventUser represents a user subscribed to a scheduled event. Member is only populated when requested with withMember.
+type ScheduledEventUser struct {
+	GuildScheduledEventID Snowflake  `json:"guild_scheduled_event_id"`
+	User                  MemberUser `json:"user"`
+	Member                *Member    `json:"member"`
+}
+
+// CreateScheduledEventParams represents the options used when creating a scheduled event.
+//
+// Stage & voice events require ChannelID. External events require EntityMetadata.Location & ScheduledEndTime & must not set ChannelID.
+// Image is the raw bytes of the cover image & can be nil for no cover image. PrivacyLevel defaults to ScheduledEventPrivacyLevelGuildOnly.
+type CreateScheduledEventParams struct {
+	ChannelID          Snowflake
+	EntityMetadata     *ScheduledEventEntityMetadata
+	Name               string
+	PrivacyLevel       int
+	ScheduledStartTime time.Time
+	ScheduledEndTime   *time.Time
+	Description        string
+	EntityType         int
+	Image              []byte
+	RecurrenceRule     *RecurrenceRule
+}
+
+// ModifyScheduledEventParams represents the fields that can be changed on a scheduled event. Nil fields are left unchanged.
+//
+// ChannelID can be set to a pointer to 0 to remove the event's channel, which Discord requires when EntityType changes to
+// ScheduledEventEntityTypeExternal. It's removed automatically if EntityType is set to external & ChannelID is nil. Status can only
+// move from scheduled to active or canceled & from active to completed.
+type ModifyScheduledEventParams struct {
+	ChannelID          *Snowflake
+	EntityMetadata     *ScheduledEventEntityMetadata
+	Name               *string
+	ScheduledStartTime *time.Time
+	ScheduledEndTime   *time.Time
+	Description        *string
+	EntityType         *int
+	Status             *int
+	Image              []byte
+	RecurrenceRule     *RecurrenceRule
+}
+
+// ValidateScheduledEventStatusTransition returns an error if a scheduled event can't move from the from status to the to status.
+//
+// Possible Errors:
+//   - ErrInvalidStatusTransition: Returned if the transition is not allowed.
+func ValidateScheduledEventStatusTransition(from int, to int) error {
+	if from == to {
+		return nil
+	}
+	switch {
+	case from == ScheduledEventStatusScheduled && (to == ScheduledEventStatusActive || to == ScheduledEventStatusCanceled):
+		return nil
+	case from == ScheduledEventStatusActive && to == ScheduledEventStatusCompleted:
+		return nil
+	}
+	return fmt.Errorf("%w: %d to %d", ErrInvalidStatusTransition, from, to)
+}
+
+// validate checks the params against the requirements of their entity type.
+func (p *CreateScheduledEventParams) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidScheduledEvent)
+	}
+	switch p.EntityType {
+	case ScheduledEventEntityTypeStageInstance, ScheduledEventEntityTypeVoice:
+		if p.ChannelID == 0 {
+			return fmt.Errorf("%w: channel ID is required for stage & voice events", ErrInvalidScheduledEvent)
+		}
+	case ScheduledEventEntityTypeExternal:
+		if p.ChannelID != 0 {
+			return fmt.Errorf("%w: channel ID must not be set for external events", ErrInvalidScheduledEvent)
+		}
+		if p.EntityMetadata == nil || p.EntityMetadata.Location == "" {
+			return fmt.Errorf("%w: location is required for external events", ErrInvalidScheduledEvent)
+		}
+		if p.ScheduledEndTime == nil {
+			return fmt.Errorf("%w: end time is required for external events", ErrInvalidScheduledEvent)
+		}
+	default:
+		return fmt.Errorf("%w: unknown entity type %d", ErrInvalidScheduledEvent, p.EntityType)
+	}
+	if p.ScheduledEndTime != nil && !p.ScheduledEndTime.After(p.ScheduledStartTime) {
+		return fmt.Errorf("%w: end time must be after start time", ErrInvalidScheduledEvent)
+	}
+	return nil
+}
+
+// ListScheduledEvents lists the scheduled events of the guild with the passed guild ID.
+//
+// If withUserCount is true the events' UserCount is populated.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) ListScheduledEvents(guildID Snowflake, withUserCount bool) ([]ScheduledEvent, error) {
+	link := b.apiURL() + "/guilds/" + guildID.String() + "/scheduled-events"
+	if withUserCount {
+		link += "?with_user_count=true"
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var events []ScheduledEvent
+	resp, err := b.Request(req, &events)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return events, nil
+}
+
+// FetchScheduledEvent fetches the scheduled event with the passed event ID from the guild with the passed guild ID.
+//
+// If withUserCount is true the event's UserCount is populated.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrScheduledEventNotFound: Returned if the event does not exist.
+func (b *Bot) FetchScheduledEvent(guildID Snowflake, eventID Snowflake, withUserCount bool) (*ScheduledEvent, error) {
+	link := b.apiURL() + "/guilds/" + guildID.String() + "/scheduled-events/" + eventID.String()
+	if withUserCount {
+		link += "?with_user_count=true"
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.scheduledEventRequest(req)
+}
+
+// CreateScheduledEvent creates a scheduled event in the guild with the passed guild ID.
+//
+// The params are validated against the requirements of their entity type before the request is made.
+//
+// Possible Errors:
+//   - ErrInvalidScheduledEvent: Returned if the params are invalid.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage events.
+func (b *Bot) CreateScheduledEvent(guildID Snowflake, params CreateScheduledEventParams) (*ScheduledEvent, error) {
+	err := params.validate()
+	if err != nil {
+		return nil, err
+	}
+	if params.PrivacyLevel == 0 {
+		params.PrivacyLevel = ScheduledEventPrivacyLevelGuildOnly
+	}
+	payload := struct {
+		ChannelID          Snowflake                     `json:"channel_id,omitempty"`
+		EntityMetadata     *ScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
+		Name               string                        `json:"name"`
+		PrivacyLevel       int                           `json:"privacy_level"`
+		ScheduledStartTime time.Time                     `json:"scheduled_start_time"`
+		ScheduledEndTime   *time.Time                    `json:"scheduled_end_time,omitempty"`
+		Description        string                        `json:"description,omitempty"`
+		EntityType         int                           `json:"entity_type"`
+		Image              string                        `json:"image,omitempty"`
+		RecurrenceRule     *RecurrenceRule               `json:"recurrence_rule,omitempty"`
+	}{
+		ChannelID:          params.ChannelID,
+		EntityMetadata:     params.EntityMetadata,
+		Name:               params.Name,
+		PrivacyLevel:       params.PrivacyLevel,
+		ScheduledStartTime: params.ScheduledStartTime,
+		ScheduledEndTime:   params.ScheduledEndTime,
+		Description:        params.Description,
+		EntityType:         params.EntityType,
+		RecurrenceRule:     params.RecurrenceRule,
+	}
+	if params.Image != nil {
+		payload.Image = util.ImageDataURI(params.Image)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/scheduled-events", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.scheduledEventRequest(req)
+}
+
+// ModifyScheduledEvent modifies the scheduled event with the passed event ID in the guild with the passed guild ID & returns the updated event.
+//
+// If Status is set the event is fetched first to validate the status transition.
+//
+// Possible Errors:
+//   - ErrInvalidStatusTransition: Returned if the event can't move to the passed status.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrScheduledEventNotFound: Returned if the event does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage events.
+func (b *Bot) ModifyScheduledEvent(guildID Snowflake, eventID Snowflake, params ModifyScheduledEventParams) (*ScheduledEvent, error) {
+	if params.Status != nil {
+		event, err := b.FetchScheduledEvent(guildID, eventID, false)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching scheduled event: %w", err)
+		}
+		err = ValidateScheduledEventStatusTransition(event.Status, *params.Status)
+		if err != nil {
+			return nil, err
+		}
+	}
+	payload := struct {
+		ChannelID          *clearableSnowflake           `json:"channel_id,omitempty"`
+		EntityMetadata     *ScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
+		Name               *string                       `json:"name,omitempty"`
+		ScheduledStartTime *time.Time                    `json:"scheduled_start_time,omitempty"`
+		ScheduledEndTime   *time.Time                    `json:"scheduled_end_time,omitempty"`
+		Description        *string                       `json:"description,omitempty"`
+		EntityType         *int                          `json:"entity_type,omitempty"`
+		Status             *int                          `json:"status,omitempty"`
+		Image              string                        `json:"image,omitempty"`
+		RecurrenceRule     *RecurrenceRule               `json:"recurrence_rule,omitempty"`
+	}{
+		ChannelID:          (*clearableSnowflake)(params.ChannelID),
+		EntityMetadata:     params.EntityMetadata,
+		Name:               params.Name,
+		ScheduledStartTime: params.ScheduledStartTime,
+		ScheduledEndTime:   params.ScheduledEndTime,
+		Description:        params.Description,
+		EntityType:         params.EntityType,
+		Status:             params.Status,
+		RecurrenceRule:     params.RecurrenceRule,
+	}
+	if params.EntityType != nil && *params.EntityType == ScheduledEventEntityTypeExternal && payload.ChannelID == nil {
+		payload.ChannelID = new(clearableSnowflake)
+	}
+	if params.Image != nil {
+		payload.Image = util.ImageDataURI(params.Image)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/scheduled-events/"+eventID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.scheduledEventRequest(req)
+}
+
+// DeleteScheduledEvent deletes the scheduled event with the passed event ID from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrScheduledEventNotFound: Returned if the event does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage events.
+func (b *Bot) DeleteScheduledEvent(guildID Snowflake, eventID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/guilds/"+guildID.String()+"/scheduled-events/"+eventID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+			if discordErr.code == 10070 {
+				return ErrScheduledEventNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
+
+// ListScheduledEventUsers lists the users subscribed to the scheduled event with the passed event ID in the guild with the passed guild ID.
+//
+// Users are sorted by user ID. Before & after are user IDs used for pagination & can be 0. Limit can be 0 for Discord's default of 100.
+// If withMember is true the users' guild member is populated.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrScheduledEventNotFound: Returned if the event does not exist.
+func (b *Bot) ListScheduledEventUsers(guildID Snowflake, eventID Snowflake, limit int, withMember bool, before Snowflake, after Snowflake) ([]ScheduledEventUser, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if withMember {
+		query.Set("with_member", "true")
+	}
+	if before != 0 {
+		query.Set("before", before.String())
+	}
+	if after != 0 {
+		query.Set("after", after.String())
+	}
+	link := b.apiURL() + "/guilds/" + guildID.String() + "/scheduled-events/" + eventID.String() + "/users"
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var users []ScheduledEventUser
+	resp, err := b.Request(req, &users)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 10070 {
+				return nil, ErrScheduledEventNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return users, nil
+}
+
+func (b *Bot) scheduledEventRequest(req *http.Request) (*ScheduledEvent, error) {
+	var event ScheduledEvent
+	resp, err := b.Request(req, &event)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 10070 {
+				return nil, ErrScheduledEventNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &event, nil
+}
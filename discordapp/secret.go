@@ -0,0 +1,116 @@
+package discordapp
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Redacted is what secrets & scrubbed values are formatted as.
+const Redacted = "[REDACTED]"
+
+// maxErrorBodyLength is the number of bytes of a response body included in an error message.
+const maxErrorBodyLength = 512
+
+// Secret is a credential such as a bot token or client secret. It formats as [REDACTED] with fmt & slog so it doesn't leak into logs.
+// Value returns the credential itself. It marshals to JSON as is, so structs holding one can still be persisted.
+//
+// Bot.Token, Application.Secret, WebhookClient.Token & InteractionClient.Token used to be strings. Untyped constants can still be
+// assigned to them, but string variables must be converted with Secret(...) & reads must use Value or string(...).
+type Secret string
+
+// Value returns the secret's value.
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String returns [REDACTED].
+func (s Secret) String() string {
+	return Redacted
+}
+
+// GoString returns [REDACTED].
+func (s Secret) GoString() string {
+	return Redacted
+}
+
+// LogValue returns [REDACTED].
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// String returns the bot's application ID & name without its token.
+func (b *Bot) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.Application == nil {
+		return fmt.Sprintf("Bot{Token: %s, ApplicationID: %s}", Redacted, b.ApplicationID)
+	}
+	return fmt.Sprintf("Bot{Token: %s, ApplicationID: %s, Name: %q}", Redacted, b.Application.ID, b.Application.Name)
+}
+
+// GoString returns the same as String.
+func (b *Bot) GoString() string {
+	return b.String()
+}
+
+// LogValue returns the bot's application ID & name without its token.
+func (b *Bot) LogValue() slog.Value {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.Application == nil {
+		return slog.GroupValue(slog.String("token", Redacted), slog.String("application_id", b.ApplicationID.String()))
+	}
+	return slog.GroupValue(slog.String("token", Redacted), slog.String("application_id", b.Application.ID.String()), slog.String("name", b.Application.Name))
+}
+
+// String returns the application's bot without its token or secret.
+func (a Application) String() string {
+	if a.Bot == nil {
+		return "Application{Secret: " + Redacted + "}"
+	}
+	return "Application{Bot: " + a.Bot.String() + ", Secret: " + Redacted + "}"
+}
+
+// GoString returns the same as String.
+func (a Application) GoString() string {
+	return a.String()
+}
+
+// LogValue returns the application's bot without its token or secret.
+func (a Application) LogValue() slog.Value {
+	if a.Bot == nil {
+		return slog.GroupValue(slog.String("secret", Redacted))
+	}
+	return slog.GroupValue(slog.Any("bot", a.Bot.LogValue()), slog.String("secret", Redacted))
+}
+
+// scrubURLError returns the *url.Error in the passed error's chain with its URL replaced with the route of the passed URL relative to the
+// passed base URL, see RouteTemplate, so webhook & interaction tokens in request paths don't leak into error messages. Errors without one
+// are returned as is.
+func scrubURLError(err error, u *url.URL, baseURL string) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return &url.Error{Op: urlErr.Op, URL: routeTemplate(u, baseURL), Err: urlErr.Err}
+	}
+	return err
+}
+
+var secretFieldPattern = regexp.MustCompile(`("(?:access_token|refresh_token|token|client_secret|secret|code)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// scrubBody returns the passed response body with the values of credential fields such as access_token replaced with [REDACTED],
+// truncated to at most maxErrorBodyLength bytes without splitting a rune.
+func scrubBody(body []byte) string {
+	scrubbed := secretFieldPattern.ReplaceAll(body, []byte(`$1"`+Redacted+`"`))
+	if len(scrubbed) > maxErrorBodyLength {
+		end := maxErrorBodyLength
+		for end > 0 && !utf8.RuneStart(scrubbed[end]) {
+			end--
+		}
+		return fmt.Sprintf("%s... (%d bytes truncated)", scrubbed[:end], len(scrubbed)-end)
+	}
+	return string(scrubbed)
+}
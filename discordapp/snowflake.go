@@ -0,0 +1,138 @@
+package discordapp
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiscordEpoch is the first millisecond of 2015 as a unix timestamp in milliseconds, the epoch of snowflake timestamps.
+const DiscordEpoch = 1420070400000
+
+// Snowflake represents the ID of a Discord object.
+//
+// Snowflakes are encoded as json strings & can be decoded from json strings or numbers. A zero Snowflake represents no ID.
+// Snowflakes are ordered by creation time, so they can be compared & sorted directly.
+type Snowflake uint64
+
+// ParseSnowflake parses the passed decimal ID. An empty string is parsed as the zero Snowflake.
+func ParseSnowflake(id string) (Snowflake, error) {
+	if id == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing snowflake %q: %w", id, err)
+	}
+	return Snowflake(value), nil
+}
+
+// SnowflakeFromTime returns the smallest snowflake created at the passed time.
+//
+// It is useful as a pagination cursor, e.g. fetching the messages after SnowflakeFromTime(t) returns the messages sent after t.
+func SnowflakeFromTime(t time.Time) Snowflake {
+	ms := t.UnixMilli() - DiscordEpoch
+	if ms < 0 {
+		return 0
+	}
+	return Snowflake(uint64(ms) << 22)
+}
+
+// String returns the snowflake in decimal, as used in Discord's API.
+func (s Snowflake) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// Time returns the time the snowflake was created at.
+func (s Snowflake) Time() time.Time {
+	return time.UnixMilli(int64(s>>22) + DiscordEpoch)
+}
+
+// WorkerID returns the ID of the internal worker that created the snowflake.
+func (s Snowflake) WorkerID() uint8 {
+	return uint8((s >> 17) & 0x1f)
+}
+
+// ProcessID returns the ID of the internal process that created the snowflake.
+func (s Snowflake) ProcessID() uint8 {
+	return uint8((s >> 12) & 0x1f)
+}
+
+// Increment returns the number of snowflakes created by the process before this one within the same millisecond.
+func (s Snowflake) Increment() uint16 {
+	return uint16(s & 0xfff)
+}
+
+// Compare returns -1 if the snowflake is less than the passed snowflake, 1 if it is greater & 0 if they are equal.
+func (s Snowflake) Compare(other Snowflake) int {
+	switch {
+	case s < other:
+		return -1
+	case s > other:
+		return 1
+	}
+	return 0
+}
+
+// MarshalJSON encodes the snowflake as a json string.
+func (s Snowflake) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(s.String())), nil
+}
+
+// clearableSnowflake is a snowflake in a request payload that encodes 0 as null, letting a pointer to 0 in Modify params clear the field
+// while nil leaves it unchanged.
+type clearableSnowflake Snowflake
+
+// MarshalJSON encodes 0 as null & other snowflakes as json strings.
+func (s clearableSnowflake) MarshalJSON() ([]byte, error) {
+	if s == 0 {
+		return []byte("null"), nil
+	}
+	return Snowflake(s).MarshalJSON()
+}
+
+// UnmarshalJSON decodes the snowflake from a json string or number. Null leaves the snowflake unchanged.
+func (s *Snowflake) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	id := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		unquoted, err := strconv.Unquote(id)
+		if err != nil {
+			return fmt.Errorf("error unquoting snowflake: %w", err)
+		}
+		id = unquoted
+	}
+	value, err := ParseSnowflake(id)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// MarshalText encodes the snowflake in decimal, allowing snowflakes to be used as json object keys.
+func (s Snowflake) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes the snowflake from decimal.
+func (s *Snowflake) UnmarshalText(text []byte) error {
+	value, err := ParseSnowflake(string(text))
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+func joinSnowflakes(ids []Snowflake, separator string) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, separator)
+}
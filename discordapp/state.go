@@ -0,0 +1,553 @@
+package discordapp
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StateConfig configures a State.
+//
+// MaxAge is how long a cached entry is returned by Bot's Fetch methods instead of making a request. 0 uses DefaultStateMaxAge, which
+// suits states only filled from REST results. A negative MaxAge means entries never go stale, which suits states kept up to date by
+// gateway events. The Max limits cap the number of entries of each kind, evicting the least
+// recently stored entry first. A limit of 0 means no limit.
+type StateConfig struct {
+	MaxAge      time.Duration
+	MaxGuilds   int
+	MaxChannels int
+	MaxRoles    int
+	MaxMembers  int
+	MaxUsers    int
+	MaxEmojis   int
+}
+
+// State caches guilds, channels, roles, members, users & emojis in memory. It's safe for concurrent use.
+//
+// A State is filled from gateway events passed to HandleEvent & from the results of Bot's methods when set as Bot.State. Bot's Fetch
+// methods return fresh entries without making a request. Returned values share slices with the state & must not be modified.
+type State struct {
+	mu       sync.RWMutex
+	config   StateConfig
+	guilds   *stateStore[Snowflake, Guild]
+	channels *stateStore[Snowflake, Channel]
+	roles    *stateStore[Snowflake, guildRole]
+	members  *stateStore[memberKey, Member]
+	users    *stateStore[Snowflake, MemberUser]
+	emojis   *stateStore[Snowflake, guildEmoji]
+}
+
+type guildRole struct {
+	guildID Snowflake
+	role    Role
+}
+
+type guildEmoji struct {
+	guildID Snowflake
+	emoji   Emoji
+}
+
+type memberKey struct {
+	guildID Snowflake
+	userID  Snowflake
+}
+
+// DefaultStateMaxAge is the MaxAge of a State whose config leaves it at 0.
+const DefaultStateMaxAge = time.Minute
+
+// NewState creates & returns a pointer to an empty state using the passed config.
+func NewState(config StateConfig) *State {
+	if config.MaxAge == 0 {
+		config.MaxAge = DefaultStateMaxAge
+	}
+	return &State{
+		config:   config,
+		guilds:   newStateStore[Snowflake, Guild](config.MaxGuilds),
+		channels: newStateStore[Snowflake, Channel](config.MaxChannels),
+		roles:    newStateStore[Snowflake, guildRole](config.MaxRoles),
+		members:  newStateStore[memberKey, Member](config.MaxMembers),
+		users:    newStateStore[Snowflake, MemberUser](config.MaxUsers),
+		emojis:   newStateStore[Snowflake, guildEmoji](config.MaxEmojis),
+	}
+}
+
+// stateStore holds entries of one kind in the order they were stored, so the least recently stored entry can be evicted.
+type stateStore[K comparable, V any] struct {
+	limit   int
+	entries map[K]*list.Element
+	order   *list.List
+}
+
+type stateEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	stored time.Time
+}
+
+func newStateStore[K comparable, V any](limit int) *stateStore[K, V] {
+	return &stateStore[K, V]{limit: limit, entries: map[K]*list.Element{}, order: list.New()}
+}
+
+func (s *stateStore[K, V]) set(key K, value V) {
+	entry := &stateEntry[K, V]{key: key, value: value, stored: time.Now()}
+	if element, ok := s.entries[key]; ok {
+		element.Value = entry
+		s.order.MoveToBack(element)
+		return
+	}
+	s.entries[key] = s.order.PushBack(entry)
+	if s.limit > 0 && s.order.Len() > s.limit {
+		oldest := s.order.Front()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*stateEntry[K, V]).key)
+	}
+}
+
+// get returns the entry with the passed key if it was stored within maxAge. A maxAge of 0 or less returns entries of any age.
+func (s *stateStore[K, V]) get(key K, maxAge time.Duration) (V, bool) {
+	element, ok := s.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry := element.Value.(*stateEntry[K, V])
+	if maxAge > 0 && time.Since(entry.stored) > maxAge {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (s *stateStore[K, V]) delete(key K) {
+	if element, ok := s.entries[key]; ok {
+		s.order.Remove(element)
+		delete(s.entries, key)
+	}
+}
+
+// each calls fn with every entry, deleting the entries for which it returns true.
+func (s *stateStore[K, V]) each(fn func(key K, value V) (remove bool)) {
+	for element := s.order.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*stateEntry[K, V])
+		if fn(entry.key, entry.value) {
+			s.order.Remove(element)
+			delete(s.entries, entry.key)
+		}
+		element = next
+	}
+}
+
+// Guild returns the cached guild with the passed guild ID regardless of its age.
+func (s *State) Guild(guildID Snowflake) (*Guild, bool) {
+	return s.guild(guildID, 0)
+}
+
+func (s *State) guild(guildID Snowflake, maxAge time.Duration) (*Guild, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	guild, ok := s.guilds.get(guildID, maxAge)
+	if !ok {
+		return nil, false
+	}
+	guild.Roles = s.guildRoles(guildID)
+	guild.Emojis = s.guildEmojis(guildID)
+	guild.Channels = s.guildChannels(guildID)
+	return &guild, true
+}
+
+// Channel returns the cached channel with the passed channel ID regardless of its age.
+func (s *State) Channel(channelID Snowflake) (*Channel, bool) {
+	return s.channel(channelID, 0)
+}
+
+func (s *State) channel(channelID Snowflake, maxAge time.Duration) (*Channel, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	channel, ok := s.channels.get(channelID, maxAge)
+	if !ok {
+		return nil, false
+	}
+	return &channel, true
+}
+
+// GuildChannels returns the cached channels of the guild with the passed guild ID.
+func (s *State) GuildChannels(guildID Snowflake) []Channel {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.guildChannels(guildID)
+}
+
+func (s *State) guildChannels(guildID Snowflake) []Channel {
+	var channels []Channel
+	s.channels.each(func(_ Snowflake, channel Channel) bool {
+		if channel.GuildID == guildID {
+			channels = append(channels, channel)
+		}
+		return false
+	})
+	return channels
+}
+
+// Role returns the cached role with the passed role ID regardless of its age.
+func (s *State) Role(roleID Snowflake) (*Role, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.roles.get(roleID, 0)
+	if !ok {
+		return nil, false
+	}
+	return &entry.role, true
+}
+
+// GuildRoles returns the cached roles of the guild with the passed guild ID.
+func (s *State) GuildRoles(guildID Snowflake) []Role {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.guildRoles(guildID)
+}
+
+func (s *State) guildRoles(guildID Snowflake) []Role {
+	var roles []Role
+	s.roles.each(func(_ Snowflake, entry guildRole) bool {
+		if entry.guildID == guildID {
+			roles = append(roles, entry.role)
+		}
+		return false
+	})
+	return roles
+}
+
+// Member returns the cached member with the passed user ID in the guild with the passed guild ID regardless of its age.
+func (s *State) Member(guildID Snowflake, userID Snowflake) (*Member, bool) {
+	return s.member(guildID, userID, 0)
+}
+
+func (s *State) member(guildID Snowflake, userID Snowflake, maxAge time.Duration) (*Member, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	member, ok := s.members.get(memberKey{guildID, userID}, maxAge)
+	if !ok {
+		return nil, false
+	}
+	if user, ok := s.users.get(userID, 0); ok {
+		member.User = user
+	}
+	return &member, true
+}
+
+// User returns the cached user with the passed user ID regardless of its age.
+func (s *State) User(userID Snowflake) (*MemberUser, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	user, ok := s.users.get(userID, 0)
+	if !ok {
+		return nil, false
+	}
+	return &user, true
+}
+
+// Emoji returns the cached emoji with the passed emoji ID regardless of its age.
+func (s *State) Emoji(emojiID Snowflake) (*Emoji, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.emojis.get(emojiID, 0)
+	if !ok {
+		return nil, false
+	}
+	return &entry.emoji, true
+}
+
+// guildEmoji returns the emoji with the passed emoji ID if it was stored within maxAge & belongs to the guild with the passed guild ID.
+func (s *State) guildEmoji(guildID Snowflake, emojiID Snowflake, maxAge time.Duration) (*Emoji, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.emojis.get(emojiID, maxAge)
+	if !ok || entry.guildID != guildID {
+		return nil, false
+	}
+	return &entry.emoji, true
+}
+
+// GuildEmojis returns the cached emojis of the guild with the passed guild ID.
+func (s *State) GuildEmojis(guildID Snowflake) []Emoji {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.guildEmojis(guildID)
+}
+
+func (s *State) guildEmojis(guildID Snowflake) []Emoji {
+	var emojis []Emoji
+	s.emojis.each(func(_ Snowflake, entry guildEmoji) bool {
+		if entry.guildID == guildID {
+			emojis = append(emojis, entry.emoji)
+		}
+		return false
+	})
+	return emojis
+}
+
+// SetGuild stores the passed guild along with its roles, emojis & channels, which are stored separately so they stay up to date. If the
+// guild's roles or emojis are not nil they replace its cached roles or emojis, dropping those that were deleted.
+func (s *State) SetGuild(guild Guild) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setGuild(guild)
+}
+
+func (s *State) setGuild(guild Guild) {
+	if guild.Roles != nil {
+		s.roles.each(func(_ Snowflake, entry guildRole) bool {
+			return entry.guildID == guild.ID
+		})
+	}
+	if guild.Emojis != nil {
+		s.emojis.each(func(_ Snowflake, entry guildEmoji) bool {
+			return entry.guildID == guild.ID
+		})
+	}
+	for _, role := range guild.Roles {
+		s.roles.set(role.ID, guildRole{guild.ID, role})
+	}
+	for _, emoji := range guild.Emojis {
+		s.emojis.set(emoji.ID, guildEmoji{guild.ID, emoji})
+	}
+	for _, channel := range guild.Channels {
+		channel.GuildID = guild.ID
+		s.channels.set(channel.ID, channel)
+	}
+	guild.Roles, guild.Emojis, guild.Channels = nil, nil, nil
+	s.guilds.set(guild.ID, guild)
+}
+
+// SetChannel stores the passed channel.
+func (s *State) SetChannel(channel Channel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channels.set(channel.ID, channel)
+}
+
+// SetRole stores the passed role of the guild with the passed guild ID.
+func (s *State) SetRole(guildID Snowflake, role Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles.set(role.ID, guildRole{guildID, role})
+}
+
+// SetMember stores the passed member of the guild with the passed guild ID & their user.
+func (s *State) SetMember(guildID Snowflake, member Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setMember(guildID, member)
+}
+
+func (s *State) setMember(guildID Snowflake, member Member) {
+	s.members.set(memberKey{guildID, member.User.ID}, member)
+	s.users.set(member.User.ID, member.User)
+}
+
+// SetUser stores the passed user.
+func (s *State) SetUser(user MemberUser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users.set(user.ID, user)
+}
+
+// SetEmoji stores the passed emoji of the guild with the passed guild ID.
+func (s *State) SetEmoji(guildID Snowflake, emoji Emoji) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emojis.set(emoji.ID, guildEmoji{guildID, emoji})
+}
+
+// RemoveGuild removes the guild with the passed guild ID along with its channels, roles, members & emojis.
+func (s *State) RemoveGuild(guildID Snowflake) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.guilds.delete(guildID)
+	s.channels.each(func(_ Snowflake, channel Channel) bool {
+		return channel.GuildID == guildID
+	})
+	s.roles.each(func(_ Snowflake, entry guildRole) bool {
+		return entry.guildID == guildID
+	})
+	s.members.each(func(key memberKey, _ Member) bool {
+		return key.guildID == guildID
+	})
+	s.emojis.each(func(_ Snowflake, entry guildEmoji) bool {
+		return entry.guildID == guildID
+	})
+}
+
+// RemoveChannel removes the channel with the passed channel ID.
+func (s *State) RemoveChannel(channelID Snowflake) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channels.delete(channelID)
+}
+
+// RemoveRole removes the role with the passed role ID.
+func (s *State) RemoveRole(roleID Snowflake) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles.delete(roleID)
+}
+
+// RemoveMember removes the member with the passed user ID from the guild with the passed guild ID. Their user is kept.
+func (s *State) RemoveMember(guildID Snowflake, userID Snowflake) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members.delete(memberKey{guildID, userID})
+}
+
+// RemoveEmoji removes the emoji with the passed emoji ID.
+func (s *State) RemoveEmoji(emojiID Snowflake) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emojis.delete(emojiID)
+}
+
+// HandleEvent updates the state from a gateway dispatch with the passed event name, such as "GUILD_CREATE", & data. Events that don't
+// affect the state are ignored.
+//
+// Handled events: GUILD_CREATE, GUILD_UPDATE, GUILD_DELETE, CHANNEL_CREATE, CHANNEL_UPDATE, CHANNEL_DELETE, THREAD_CREATE,
+// THREAD_UPDATE, THREAD_DELETE, GUILD_ROLE_CREATE, GUILD_ROLE_UPDATE, GUILD_ROLE_DELETE, GUILD_MEMBER_ADD, GUILD_MEMBER_UPDATE,
+// GUILD_MEMBER_REMOVE, GUILD_MEMBERS_CHUNK, GUILD_EMOJIS_UPDATE & USER_UPDATE.
+func (s *State) HandleEvent(name string, data json.RawMessage) error {
+	var err error
+	switch name {
+	case "GUILD_CREATE", "GUILD_UPDATE":
+		var event struct {
+			Guild
+			Members []Member  `json:"members"`
+			Threads []Channel `json:"threads"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil {
+			s.mu.Lock()
+			s.setGuild(event.Guild)
+			for _, member := range event.Members {
+				s.setMember(event.ID, member)
+			}
+			for _, thread := range event.Threads {
+				thread.GuildID = event.ID
+				s.channels.set(thread.ID, thread)
+			}
+			s.mu.Unlock()
+		}
+	case "GUILD_DELETE":
+		var event struct {
+			ID          Snowflake `json:"id"`
+			Unavailable bool      `json:"unavailable"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil && event.Unavailable {
+			// The guild is in an outage, not deleted, so it's kept until a GUILD_CREATE marks it available again.
+			s.mu.Lock()
+			if guild, ok := s.guilds.get(event.ID, 0); ok {
+				guild.Unavailable = true
+				s.guilds.set(event.ID, guild)
+			}
+			s.mu.Unlock()
+		} else if err == nil {
+			s.RemoveGuild(event.ID)
+		}
+	case "CHANNEL_CREATE", "CHANNEL_UPDATE", "THREAD_CREATE", "THREAD_UPDATE":
+		var channel Channel
+		err = json.Unmarshal(data, &channel)
+		if err == nil {
+			s.SetChannel(channel)
+		}
+	case "CHANNEL_DELETE", "THREAD_DELETE":
+		var channel Channel
+		err = json.Unmarshal(data, &channel)
+		if err == nil {
+			s.RemoveChannel(channel.ID)
+		}
+	case "GUILD_ROLE_CREATE", "GUILD_ROLE_UPDATE":
+		var event struct {
+			GuildID Snowflake `json:"guild_id"`
+			Role    Role      `json:"role"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil {
+			s.SetRole(event.GuildID, event.Role)
+		}
+	case "GUILD_ROLE_DELETE":
+		var event struct {
+			RoleID Snowflake `json:"role_id"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil {
+			s.RemoveRole(event.RoleID)
+		}
+	case "GUILD_MEMBER_ADD", "GUILD_MEMBER_UPDATE":
+		var event struct {
+			GuildID Snowflake `json:"guild_id"`
+			User    struct {
+				ID Snowflake `json:"id"`
+			} `json:"user"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil {
+			s.mu.Lock()
+			// GUILD_MEMBER_UPDATE holds a subset of the member's fields, so it's applied on top of the cached member.
+			member, _ := s.members.get(memberKey{event.GuildID, event.User.ID}, 0)
+			err = json.Unmarshal(data, &member)
+			if err == nil {
+				s.setMember(event.GuildID, member)
+			}
+			s.mu.Unlock()
+		}
+	case "GUILD_MEMBER_REMOVE":
+		var event struct {
+			GuildID Snowflake  `json:"guild_id"`
+			User    MemberUser `json:"user"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil {
+			s.RemoveMember(event.GuildID, event.User.ID)
+		}
+	case "GUILD_MEMBERS_CHUNK":
+		var event struct {
+			GuildID Snowflake `json:"guild_id"`
+			Members []Member  `json:"members"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil {
+			s.mu.Lock()
+			for _, member := range event.Members {
+				s.setMember(event.GuildID, member)
+			}
+			s.mu.Unlock()
+		}
+	case "GUILD_EMOJIS_UPDATE":
+		var event struct {
+			GuildID Snowflake `json:"guild_id"`
+			Emojis  []Emoji   `json:"emojis"`
+		}
+		err = json.Unmarshal(data, &event)
+		if err == nil {
+			s.mu.Lock()
+			s.emojis.each(func(_ Snowflake, entry guildEmoji) bool {
+				return entry.guildID == event.GuildID
+			})
+			for _, emoji := range event.Emojis {
+				s.emojis.set(emoji.ID, guildEmoji{event.GuildID, emoji})
+			}
+			s.mu.Unlock()
+		}
+	case "USER_UPDATE":
+		var user MemberUser
+		err = json.Unmarshal(data, &user)
+		if err == nil {
+			s.SetUser(user)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error unmarshaling %s event: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// Sticker types
+const (
+	StickerTypeStandard = 1
+	StickerTypeGuild    = 2
+)
+
+// Sticker format types
+const (
+	StickerFormatPNG    = 1
+	StickerFormatAPNG   = 2
+	StickerFormatLottie = 3
+	StickerFormatGIF    = 4
+)
+
+// MaxStickerSize is the max size in bytes of a sticker file.
+const MaxStickerSize = 512 * 1024
+
+// Sticker represents a sticker object returned by Discord's API.
+type Sticker struct {
+	ID          Snowflake   `json:"id"`
+	PackID      Snowflake   `json:"pack_id"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	Tags        string      `json:"tags"`
+	Type        int         `json:"type"`
+	FormatType  int         `json:"format_type"`
+	Available   bool        `json:"available"`
+	GuildID     Snowflake   `json:"guild_id"`
+	User        *MemberUser `json:"user"`
+	SortValue   int         `json:"sort_value"`
+}
+
+// CreateGuildStickerParams represents the options used when creating a guild sticker.
+//
+// Tags is the name of a unicode emoji used for autocomplete suggestions. File must be a PNG, APNG, GIF or Lottie JSON file no larger than MaxStickerSize.
+type CreateGuildStickerParams struct {
+	Name        string
+	Description string
+	Tags        string
+	File        []byte
+}
+
+// ModifyGuildStickerParams represents the fields that can be changed on a guild sticker. Nil fields are left unchanged.
+type ModifyGuildStickerParams struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Tags        *string `json:"tags,omitempty"`
+}
+
+// DetectStickerFormat returns the sticker format type of the passed sticker file.
+//
+// Possible Errors:
+//   - ErrInvalidStickerFile: Returned if the file is not a PNG, APNG, GIF or Lottie JSON file or is larger than MaxStickerSize.
+func DetectStickerFormat(file []byte) (int, error) {
+	if len(file) > MaxStickerSize {
+		return 0, fmt.Errorf("%w: file is larger than %d bytes", ErrInvalidStickerFile, MaxStickerSize)
+	}
+	switch {
+	case bytes.HasPrefix(file, []byte("\x89PNG\r\n\x1a\n")):
+		// An APNG is a PNG with an animation control chunk before the first image data chunk.
+		idat := bytes.Index(file, []byte("IDAT"))
+		actl := bytes.Index(file, []byte("acTL"))
+		if actl != -1 && (idat == -1 || actl < idat) {
+			return StickerFormatAPNG, nil
+		}
+		return StickerFormatPNG, nil
+	case bytes.HasPrefix(file, []byte("GIF87a")), bytes.HasPrefix(file, []byte("GIF89a")):
+		return StickerFormatGIF, nil
+	}
+	var lottie struct {
+		Version *string `json:"v"`
+		Layers  []any   `json:"layers"`
+	}
+	if json.Unmarshal(file, &lottie) == nil && lottie.Version != nil && lottie.Layers != nil {
+		return StickerFormatLottie, nil
+	}
+	return 0, fmt.Errorf("%w: file is not a PNG, APNG, GIF or Lottie JSON file", ErrInvalidStickerFile)
+}
+
+// ListGuildStickers lists the stickers of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) ListGuildStickers(guildID Snowflake) ([]Sticker, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/stickers", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var stickers []Sticker
+	resp, err := b.Request(req, &stickers)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return stickers, nil
+}
+
+// FetchGuildSticker fetches the sticker with the passed sticker ID from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrStickerNotFound: Returned if the sticker does not exist in the guild.
+func (b *Bot) FetchGuildSticker(guildID Snowflake, stickerID Snowflake) (*Sticker, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/stickers/"+stickerID.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.stickerRequest(req)
+}
+
+// CreateGuildSticker uploads a sticker to the guild with the passed guild ID.
+//
+// The file is validated before being uploaded. Lottie stickers can only be uploaded to verified & partnered guilds.
+//
+// Possible Errors:
+//   - ErrInvalidStickerFile: Returned if the file is not a valid sticker file.
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage expressions.
+func (b *Bot) CreateGuildSticker(guildID Snowflake, params CreateGuildStickerParams) (*Sticker, error) {
+	format, err := DetectStickerFormat(params.File)
+	if err != nil {
+		return nil, err
+	}
+	filename, contentType := "sticker.png", "image/png"
+	switch format {
+	case StickerFormatGIF:
+		filename, contentType = "sticker.gif", "image/gif"
+	case StickerFormatLottie:
+		filename, contentType = "sticker.json", "application/json"
+	}
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, field := range [][2]string{{"name", params.Name}, {"description", params.Description}, {"tags", params.Tags}} {
+		err = writer.WriteField(field[0], field[1])
+		if err != nil {
+			return nil, fmt.Errorf("error writing %s field: %w", field[0], err)
+		}
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("error creating file part: %w", err)
+	}
+	_, err = part.Write(params.File)
+	if err != nil {
+		return nil, fmt.Errorf("error writing file part: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error closing multipart writer: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/stickers", &body)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return b.stickerRequest(req)
+}
+
+// ModifyGuildSticker modifies the sticker with the passed sticker ID in the guild with the passed guild ID & returns the updated sticker.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrStickerNotFound: Returned if the sticker does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage expressions.
+func (b *Bot) ModifyGuildSticker(guildID Snowflake, stickerID Snowflake, params ModifyGuildStickerParams) (*Sticker, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/stickers/"+stickerID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.stickerRequest(req)
+}
+
+// DeleteGuildSticker deletes the sticker with the passed sticker ID from the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrStickerNotFound: Returned if the sticker does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage expressions.
+func (b *Bot) DeleteGuildSticker(guildID Snowflake, stickerID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/guilds/"+guildID.String()+"/stickers/"+stickerID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return ErrGuildNotFound
+			}
+			if discordErr.code == 10060 {
+				return ErrStickerNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
+
+func (b *Bot) stickerRequest(req *http.Request) (*Sticker, error) {
+	var sticker Sticker
+	resp, err := b.Request(req, &sticker)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 10060 {
+				return nil, ErrStickerNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK && resp.Status != http.StatusCreated {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &sticker, nil
+}
@@ -0,0 +1,225 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp/util"
+)
+
+// GuildTemplate represents a guild template returned by Discord's API.
+//
+// SerializedSourceGuild is a snapshot of the source guild's settings, roles & channels.
+// The IDs of its roles & channels are placeholders local to the template, the @everyone role always having ID 0.
+type GuildTemplate struct {
+	Code                  string      `json:"code"`
+	Name                  string      `json:"name"`
+	Description           *string     `json:"description"`
+	UsageCount            int         `json:"usage_count"`
+	CreatorID             Snowflake   `json:"creator_id"`
+	Creator               *MemberUser `json:"creator"`
+	CreatedAt             time.Time   `json:"created_at"`
+	UpdatedAt             time.Time   `json:"updated_at"`
+	SourceGuildID         Snowflake   `json:"source_guild_id"`
+	SerializedSourceGuild *Guild      `json:"serialized_source_guild"`
+	IsDirty               *bool       `json:"is_dirty"`
+}
+
+// ModifyGuildTemplateParams represents the fields that can be changed on a guild template. Nil fields are left unchanged.
+type ModifyGuildTemplateParams struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// FetchGuildTemplate fetches the guild template with the passed code.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildTemplateNotFound: Returned if the template does not exist.
+func (b *Bot) FetchGuildTemplate(code string) (*GuildTemplate, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/templates/"+code, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.templateRequest(req)
+}
+
+// CreateGuildFromTemplate creates a new guild owned by the bot from the guild template with the passed code.
+//
+// Icon is the raw bytes of the guild's icon & can be nil. Bots can only create guilds while they are in less than 10 guilds.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildTemplateNotFound: Returned if the template does not exist.
+//   - ErrMaxGuilds: Returned if the bot is in too many guilds to create one.
+func (b *Bot) CreateGuildFromTemplate(code string, name string, icon []byte) (*Guild, error) {
+	payload := struct {
+		Name string `json:"name"`
+		Icon string `json:"icon,omitempty"`
+	}{Name: name}
+	if icon != nil {
+		payload.Icon = util.ImageDataURI(icon)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/templates/"+code, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var guild Guild
+	resp, err := b.Request(req, &guild)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10057 {
+				return nil, ErrGuildTemplateNotFound
+			}
+			if discordErr.code == 30001 {
+				return nil, ErrMaxGuilds
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK && resp.Status != http.StatusCreated {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &guild, nil
+}
+
+// ListGuildTemplates lists the templates of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ListGuildTemplates(guildID Snowflake) ([]GuildTemplate, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/templates", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var templates []GuildTemplate
+	err = b.guildRequest(req, &templates)
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// CreateGuildTemplate creates a template from the current state of the guild with the passed guild ID. Description can be "".
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+//   - ErrGuildTemplateExists: Returned if the guild already has a template.
+func (b *Bot) CreateGuildTemplate(guildID Snowflake, name string, description string) (*GuildTemplate, error) {
+	payload := struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}{name, description}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/guilds/"+guildID.String()+"/templates", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.templateRequest(req)
+}
+
+// SyncGuildTemplate updates the template with the passed code to the current state of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrGuildTemplateNotFound: Returned if the template does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) SyncGuildTemplate(guildID Snowflake, code string) (*GuildTemplate, error) {
+	req, err := http.NewRequest(http.MethodPut, b.apiURL()+"/guilds/"+guildID.String()+"/templates/"+code, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.templateRequest(req)
+}
+
+// ModifyGuildTemplate modifies the template with the passed code in the guild with the passed guild ID & returns the updated template.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrGuildTemplateNotFound: Returned if the template does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ModifyGuildTemplate(guildID Snowflake, code string, params ModifyGuildTemplateParams) (*GuildTemplate, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/templates/"+code, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.templateRequest(req)
+}
+
+// DeleteGuildTemplate deletes the template with the passed code from the guild with the passed guild ID & returns the deleted template.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrGuildTemplateNotFound: Returned if the template does not exist in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) DeleteGuildTemplate(guildID Snowflake, code string) (*GuildTemplate, error) {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/guilds/"+guildID.String()+"/templates/"+code, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	return b.templateRequest(req)
+}
+
+func (b *Bot) templateRequest(req *http.Request) (*GuildTemplate, error) {
+	var template GuildTemplate
+	resp, err := b.Request(req, &template)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 10057 {
+				return nil, ErrGuildTemplateNotFound
+			}
+			if discordErr.code == 30031 {
+				return nil, ErrGuildTemplateExists
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK && resp.Status != http.StatusCreated {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &template, nil
+}
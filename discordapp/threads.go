@@ -0,0 +1,438 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Thread auto archive durations in minutes
+const (
+	ThreadAutoArchiveDurationHour      = 60
+	ThreadAutoArchiveDurationDay       = 1440
+	ThreadAutoArchiveDurationThreeDays = 4320
+	ThreadAutoArchiveDurationWeek      = 10080
+)
+
+// ThreadMetadata represents the thread specific fields of a thread channel.
+type ThreadMetadata struct {
+	Archived            bool       `json:"archived"`
+	AutoArchiveDuration int        `json:"auto_archive_duration"`
+	ArchiveTimestamp    time.Time  `json:"archive_timestamp"`
+	Locked              bool       `json:"locked"`
+	Invitable           *bool      `json:"invitable"`
+	CreateTimestamp     *time.Time `json:"create_timestamp"`
+}
+
+// ThreadMember represents a user that has joined a thread.
+//
+// Member is only populated when requested with withMember.
+type ThreadMember struct {
+	ID            Snowflake `json:"id"`
+	UserID        Snowflake `json:"user_id"`
+	JoinTimestamp time.Time `json:"join_timestamp"`
+	Flags         int       `json:"flags"`
+	Member        *Member   `json:"member"`
+}
+
+// ForumTag represents a tag that can be applied to threads in a forum or media channel.
+//
+// ID should be 0 when creating a new tag. At most one of EmojiID & EmojiName can be set.
+type ForumTag struct {
+	ID        Snowflake  `json:"id,omitempty"`
+	Name      string     `json:"name"`
+	Moderated bool       `json:"moderated"`
+	EmojiID   *Snowflake `json:"emoji_id"`
+	EmojiName *string    `json:"emoji_name"`
+}
+
+// DefaultReaction represents the emoji shown in the add reaction button on threads in a forum or media channel.
+type DefaultReaction struct {
+	EmojiID   *Snowflake `json:"emoji_id"`
+	EmojiName *string    `json:"emoji_name"`
+}
+
+// ThreadList represents a list of threads along with the thread member objects of the threads the bot has joined.
+//
+// HasMore is true if there are additional threads that can be fetched by passing the archive timestamp of the last thread as before.
+type ThreadList struct {
+	Threads []Channel      `json:"threads"`
+	Members []ThreadMember `json:"members"`
+	HasMore bool           `json:"has_more"`
+}
+
+// StartThreadParams represents the options used when starting a thread.
+//
+// Type & Invitable are only used when starting a thread without a message. Type defaults to ChannelTypePrivateThread.
+type StartThreadParams struct {
+	Name                string `json:"name"`
+	AutoArchiveDuration int    `json:"auto_archive_duration,omitempty"`
+	Type                int    `json:"type,omitempty"`
+	Invitable           *bool  `json:"invitable,omitempty"`
+	RateLimitPerUser    int    `json:"rate_limit_per_user,omitempty"`
+}
+
+// ForumThreadMessageParams represents the initial message of a forum or media channel post.
+type ForumThreadMessageParams struct {
+	Content         string           `json:"content,omitempty"`
+	Embeds          []Embed          `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Flags           int              `json:"flags,omitempty"`
+}
+
+// StartForumThreadParams represents the options used when starting a post in a forum or media channel.
+//
+// AppliedTags are the IDs of the channel's forum tags to apply to the post.
+type StartForumThreadParams struct {
+	Name                string                   `json:"name"`
+	AutoArchiveDuration int                      `json:"auto_archive_duration,omitempty"`
+	RateLimitPerUser    int                      `json:"rate_limit_per_user,omitempty"`
+	Message             ForumThreadMessageParams `json:"message"`
+	AppliedTags         []Snowflake              `json:"applied_tags,omitempty"`
+}
+
+// StartThreadFromMessage starts a thread from the message with the passed message ID in the channel with the passed channel ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMessageNotFound: Returned if the message does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to create threads.
+func (b *Bot) StartThreadFromMessage(channelID Snowflake, messageID Snowflake, params StartThreadParams) (*Channel, error) {
+	payload := params
+	payload.Type = 0
+	payload.Invitable = nil
+	return b.startThread(b.apiURL()+"/channels/"+channelID.String()+"/messages/"+messageID.String()+"/threads", payload)
+}
+
+// StartThreadWithoutMessage starts a thread that is not connected to a message in the channel with the passed channel ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to create threads.
+func (b *Bot) StartThreadWithoutMessage(channelID Snowflake, params StartThreadParams) (*Channel, error) {
+	return b.startThread(b.apiURL()+"/channels/"+channelID.String()+"/threads", params)
+}
+
+// StartForumThread starts a post with an initial message in the forum or media channel with the passed channel ID.
+//
+// The returned channel's ID is also the ID of the initial message.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to send messages in the channel.
+func (b *Bot) StartForumThread(channelID Snowflake, params StartForumThreadParams) (*Channel, error) {
+	return b.startThread(b.apiURL()+"/channels/"+channelID.String()+"/threads", params)
+}
+
+func (b *Bot) startThread(link string, payload any) (*Channel, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, link, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var thread Channel
+	resp, err := b.Request(req, &thread)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 10008 {
+				return nil, ErrMessageNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusCreated && resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &thread, nil
+}
+
+// JoinThread adds the bot to the thread with the passed thread ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the thread does not exist or the bot can't see it.
+func (b *Bot) JoinThread(threadID Snowflake) error {
+	return b.threadMemberRequest(http.MethodPut, threadID, "@me")
+}
+
+// LeaveThread removes the bot from the thread with the passed thread ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the thread does not exist or the bot can't see it.
+func (b *Bot) LeaveThread(threadID Snowflake) error {
+	return b.threadMemberRequest(http.MethodDelete, threadID, "@me")
+}
+
+// AddThreadMember adds the user with the passed user ID to the thread with the passed thread ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the thread does not exist or the bot can't see it.
+//   - ErrUserNotFound: Returned if the user does not exist or is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to send messages in the thread.
+func (b *Bot) AddThreadMember(threadID Snowflake, userID Snowflake) error {
+	return b.threadMemberRequest(http.MethodPut, threadID, userID.String())
+}
+
+// RemoveThreadMember removes the user with the passed user ID from the thread with the passed thread ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the thread does not exist or the bot can't see it.
+//   - ErrUserNotFound: Returned if the user does not exist or is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage threads.
+func (b *Bot) RemoveThreadMember(threadID Snowflake, userID Snowflake) error {
+	return b.threadMemberRequest(http.MethodDelete, threadID, userID.String())
+}
+
+func (b *Bot) threadMemberRequest(method string, threadID Snowflake, user string) error {
+	req, err := http.NewRequest(method, b.apiURL()+"/channels/"+threadID.String()+"/thread-members/"+user, nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return ErrChannelNotFound
+			}
+			if discordErr.code == 10007 || discordErr.code == 10013 {
+				return ErrUserNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
+
+// FetchThreadMember fetches the thread member of the user with the passed user ID in the thread with the passed thread ID.
+//
+// If withMember is true the thread member's guild member is populated.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the thread does not exist or the bot can't see it.
+//   - ErrUserNotFound: Returned if the user is not a member of the thread.
+func (b *Bot) FetchThreadMember(threadID Snowflake, userID Snowflake, withMember bool) (*ThreadMember, error) {
+	link := b.apiURL() + "/channels/" + threadID.String() + "/thread-members/" + userID.String()
+	if withMember {
+		link += "?with_member=true"
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var member ThreadMember
+	resp, err := b.Request(req, &member)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 10007 || discordErr.code == 10013 {
+				return nil, ErrUserNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &member, nil
+}
+
+// ListThreadMembers lists the members of the thread with the passed thread ID.
+//
+// Results are paginated by user ID when withMember is true: after can be 0 to start from the beginning & limit can be 0 for Discord's default of 100.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the thread does not exist or the bot can't see it.
+func (b *Bot) ListThreadMembers(threadID Snowflake, withMember bool, after Snowflake, limit int) ([]ThreadMember, error) {
+	query := url.Values{}
+	if withMember {
+		query.Set("with_member", "true")
+	}
+	if after != 0 {
+		query.Set("after", after.String())
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	link := b.apiURL() + "/channels/" + threadID.String() + "/thread-members"
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var members []ThreadMember
+	resp, err := b.Request(req, &members)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return members, nil
+}
+
+// ListActiveGuildThreads lists all active threads in the guild with the passed guild ID that the bot can see.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+func (b *Bot) ListActiveGuildThreads(guildID Snowflake) (*ThreadList, error) {
+	return b.listThreads(b.apiURL() + "/guilds/" + guildID.String() + "/threads/active")
+}
+
+// ListPublicArchivedThreads lists the archived public threads of the channel with the passed channel ID, newest first.
+//
+// Before can be nil to start from the newest thread. To fetch the next page pass the archive timestamp of the last returned thread.
+// Limit can be 0 for no limit.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to read message history.
+func (b *Bot) ListPublicArchivedThreads(channelID Snowflake, before *time.Time, limit int) (*ThreadList, error) {
+	return b.listThreads(archivedThreadsURL(b.apiURL()+"/channels/"+channelID.String()+"/threads/archived/public", before, limit))
+}
+
+// ListPrivateArchivedThreads lists the archived private threads of the channel with the passed channel ID, newest first.
+//
+// Before can be nil to start from the newest thread. To fetch the next page pass the archive timestamp of the last returned thread.
+// Limit can be 0 for no limit.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage threads.
+func (b *Bot) ListPrivateArchivedThreads(channelID Snowflake, before *time.Time, limit int) (*ThreadList, error) {
+	return b.listThreads(archivedThreadsURL(b.apiURL()+"/channels/"+channelID.String()+"/threads/archived/private", before, limit))
+}
+
+// ListJoinedPrivateArchivedThreads lists the archived private threads of the channel with the passed channel ID that the bot has joined.
+//
+// Before is a thread ID & can be 0 to start from the newest thread. To fetch the next page pass the ID of the last returned thread.
+// Limit can be 0 for no limit.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist or the bot can't see it.
+func (b *Bot) ListJoinedPrivateArchivedThreads(channelID Snowflake, before Snowflake, limit int) (*ThreadList, error) {
+	query := url.Values{}
+	if before != 0 {
+		query.Set("before", before.String())
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	link := b.apiURL() + "/channels/" + channelID.String() + "/users/@me/threads/archived/private"
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return b.listThreads(link)
+}
+
+func archivedThreadsURL(link string, before *time.Time, limit int) string {
+	query := url.Values{}
+	if before != nil {
+		query.Set("before", before.UTC().Format(time.RFC3339Nano))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link
+}
+
+func (b *Bot) listThreads(link string) (*ThreadList, error) {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var threads ThreadList
+	resp, err := b.Request(req, &threads)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &threads, nil
+}
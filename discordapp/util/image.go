@@ -0,0 +1,13 @@
+package util
+
+import (
+	"encoding/base64"
+	"net/http"
+)
+
+// ImageDataURI encodes the passed image as a base64 data URI as expected by Discord's API for image fields such as avatars & icons.
+//
+// The content type is detected from the image bytes.
+func ImageDataURI(image []byte) string {
+	return "data:" + http.DetectContentType(image) + ";base64," + base64.StdEncoding.EncodeToString(image)
+}
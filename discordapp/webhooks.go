@@ -0,0 +1,482 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kodishim/discordapp/discordapp/util"
+)
+
+// Webhook types
+const (
+	WebhookTypeIncoming        = 1
+	WebhookTypeChannelFollower = 2
+	WebhookTypeApplication     = 3
+)
+
+// Webhook represents a webhook object returned by Discord's API.
+//
+// Token is only present on incoming webhooks & is required to execute the webhook.
+type Webhook struct {
+	ID            Snowflake   `json:"id"`
+	Type          int         `json:"type"`
+	GuildID       Snowflake   `json:"guild_id"`
+	ChannelID     Snowflake   `json:"channel_id"`
+	User          *MemberUser `json:"user"`
+	Name          string      `json:"name"`
+	Avatar        string      `json:"avatar"`
+	Token         string      `json:"token"`
+	ApplicationID Snowflake   `json:"application_id"`
+	URL           string      `json:"url"`
+}
+
+// ModifyWebhookParams represents the fields that can be changed on a webhook. Nil fields are left unchanged.
+//
+// Avatar should be a data URI, see util.ImageDataURI.
+type ModifyWebhookParams struct {
+	Name      *string    `json:"name,omitempty"`
+	Avatar    *string    `json:"avatar,omitempty"`
+	ChannelID *Snowflake `json:"channel_id,omitempty"`
+}
+
+// ExecuteWebhookParams represents the message sent when executing a webhook.
+//
+// At least one of Content, Embeds or Files must be set. Username & AvatarURL override the webhook's default name & avatar.
+type ExecuteWebhookParams struct {
+	Content         string           `json:"content,omitempty"`
+	Username        string           `json:"username,omitempty"`
+	AvatarURL       string           `json:"avatar_url,omitempty"`
+	TTS             bool             `json:"tts,omitempty"`
+	Embeds          []Embed          `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Flags           int              `json:"flags,omitempty"`
+	ThreadName      string           `json:"thread_name,omitempty"`
+	AppliedTags     []Snowflake      `json:"applied_tags,omitempty"`
+	Files           []File           `json:"-"`
+}
+
+// EditWebhookMessageParams represents the fields that can be changed on a message sent by a webhook. Nil fields are left unchanged.
+//
+// Attachments lists the existing attachments the message keeps, the rest are removed. If it's nil the existing attachments are kept &
+// Files are appended to them.
+type EditWebhookMessageParams struct {
+	Content         *string          `json:"content,omitempty"`
+	Embeds          *[]Embed         `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Attachments     *[]Attachment    `json:"attachments,omitempty"`
+	Files           []File           `json:"-"`
+}
+
+// WebhookClient executes & manages the messages of a webhook using the webhook's token for authentication.
+//
+// A bot token is not required.
+//
+// BaseURL is the base URL of Discord's API the client sends requests to. "" uses BaseDiscordAPIURL. HTTPClient is the client it sends
+// requests with. nil uses the package's HTTPClient.
+type WebhookClient struct {
+	ID         Snowflake
+	Token      Secret
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewWebhookClient creates & returns a pointer to a webhook client using the passed webhook ID & token.
+func NewWebhookClient(webhookID Snowflake, token string) *WebhookClient {
+	return &WebhookClient{ID: webhookID, Token: Secret(token)}
+}
+
+// NewWebhookClientFromURL creates & returns a pointer to a webhook client from a webhook URL such as https://discord.com/api/webhooks/{id}/{token}.
+func NewWebhookClientFromURL(webhookURL string) (*WebhookClient, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url: %w", err)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 || parts[len(parts)-3] != "webhooks" {
+		return nil, fmt.Errorf("invalid webhook url: %s", webhookURL)
+	}
+	webhookID, err := ParseSnowflake(parts[len(parts)-2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook url: %s", webhookURL)
+	}
+	return NewWebhookClient(webhookID, parts[len(parts)-1]), nil
+}
+
+// Client returns a webhook client for the webhook. The webhook must have a token.
+func (w *Webhook) Client() *WebhookClient {
+	return NewWebhookClient(w.ID, w.Token)
+}
+
+// CreateWebhook creates a webhook in the channel with the passed channel ID. Avatar can be nil for no avatar.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage webhooks.
+func (b *Bot) CreateWebhook(channelID Snowflake, name string, avatar []byte) (*Webhook, error) {
+	payload := struct {
+		Name   string `json:"name"`
+		Avatar string `json:"avatar,omitempty"`
+	}{Name: name}
+	if avatar != nil {
+		payload.Avatar = util.ImageDataURI(avatar)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.apiURL()+"/channels/"+channelID.String()+"/webhooks", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var webhook Webhook
+	resp, err := b.Request(req, &webhook)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &webhook, nil
+}
+
+// FetchChannelWebhooks fetches the webhooks of the channel with the passed channel ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrChannelNotFound: Returned if the channel does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage webhooks.
+func (b *Bot) FetchChannelWebhooks(channelID Snowflake) ([]Webhook, error) {
+	return b.fetchWebhooks(b.apiURL() + "/channels/" + channelID.String() + "/webhooks")
+}
+
+// FetchGuildWebhooks fetches the webhooks of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage webhooks.
+func (b *Bot) FetchGuildWebhooks(guildID Snowflake) ([]Webhook, error) {
+	return b.fetchWebhooks(b.apiURL() + "/guilds/" + guildID.String() + "/webhooks")
+}
+
+func (b *Bot) fetchWebhooks(link string) ([]Webhook, error) {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var webhooks []Webhook
+	resp, err := b.Request(req, &webhooks)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return webhooks, nil
+}
+
+// FetchWebhook fetches the webhook with the passed webhook ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrWebhookNotFound: Returned if the webhook does not exist.
+func (b *Bot) FetchWebhook(webhookID Snowflake) (*Webhook, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/webhooks/"+webhookID.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var webhook Webhook
+	resp, err := b.Request(req, &webhook)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10015 {
+				return nil, ErrWebhookNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &webhook, nil
+}
+
+// ModifyWebhook modifies the webhook with the passed webhook ID & returns the updated webhook.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrWebhookNotFound: Returned if the webhook does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage webhooks.
+func (b *Bot) ModifyWebhook(webhookID Snowflake, params ModifyWebhookParams) (*Webhook, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/webhooks/"+webhookID.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var webhook Webhook
+	resp, err := b.Request(req, &webhook)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10015 {
+				return nil, ErrWebhookNotFound
+			}
+			if discordErr.code == 50013 {
+				return nil, ErrMissingPermissions
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook deletes the webhook with the passed webhook ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrWebhookNotFound: Returned if the webhook does not exist.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage webhooks.
+func (b *Bot) DeleteWebhook(webhookID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, b.apiURL()+"/webhooks/"+webhookID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := b.Request(req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10015 {
+				return ErrWebhookNotFound
+			}
+			if discordErr.code == 50013 {
+				return ErrMissingPermissions
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
+
+// apiURL returns the base URL the client sends requests to.
+func (w *WebhookClient) apiURL() string {
+	if w.BaseURL != "" {
+		return w.BaseURL
+	}
+	return BaseDiscordAPIURL
+}
+
+// httpClient returns the client the webhook client sends requests with.
+func (w *WebhookClient) httpClient() *http.Client {
+	if w.HTTPClient != nil {
+		return w.HTTPClient
+	}
+	return HTTPClient
+}
+
+// messageURL returns the URL of the webhook message with the passed message ID. ThreadID can be 0 if the message is not in a thread.
+func (w *WebhookClient) messageURL(messageID Snowflake, threadID Snowflake) string {
+	link := w.apiURL() + "/webhooks/" + w.ID.String() + "/" + w.Token.Value() + "/messages/" + messageID.String()
+	if threadID != 0 {
+		link += "?thread_id=" + threadID.String()
+	}
+	return link
+}
+
+// Execute sends a message using the webhook. ThreadID can be 0 to send the message to the webhook's channel.
+//
+// If wait is true Discord waits for the message to be saved & the created message is returned, otherwise the returned message is nil.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the webhook's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrWebhookNotFound: Returned if the webhook does not exist.
+//   - ErrChannelNotFound: Returned if the thread does not exist.
+func (w *WebhookClient) Execute(params ExecuteWebhookParams, wait bool, threadID Snowflake) (*Message, error) {
+	query := url.Values{}
+	if wait {
+		query.Set("wait", "true")
+	}
+	if threadID != 0 {
+		query.Set("thread_id", threadID.String())
+	}
+	link := w.apiURL() + "/webhooks/" + w.ID.String() + "/" + w.Token.Value()
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	req, err := newMessageRequest(http.MethodPost, link, params, params.Files)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var message Message
+	resp, err := request(w.httpClient(), w.apiURL(), req, &message)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10015 {
+				return nil, ErrWebhookNotFound
+			}
+			if discordErr.code == 10003 {
+				return nil, ErrChannelNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if !wait {
+		if resp.Status != http.StatusNoContent {
+			return nil, &UnexpectedResponseError{resp}
+		}
+		return nil, nil
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &message, nil
+}
+
+// FetchMessage fetches a message previously sent by the webhook. ThreadID can be 0 if the message is not in a thread.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the webhook's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrWebhookNotFound: Returned if the webhook does not exist.
+//   - ErrMessageNotFound: Returned if the message does not exist or was not sent by the webhook.
+func (w *WebhookClient) FetchMessage(messageID Snowflake, threadID Snowflake) (*Message, error) {
+	req, err := http.NewRequest(http.MethodGet, w.messageURL(messageID, threadID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var message Message
+	resp, err := request(w.httpClient(), w.apiURL(), req, &message)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10015 {
+				return nil, ErrWebhookNotFound
+			}
+			if discordErr.code == 10008 {
+				return nil, ErrMessageNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &message, nil
+}
+
+// EditMessage edits a message previously sent by the webhook & returns the edited message. ThreadID can be 0 if the message is not in a thread.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the webhook's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrWebhookNotFound: Returned if the webhook does not exist.
+//   - ErrMessageNotFound: Returned if the message does not exist or was not sent by the webhook.
+func (w *WebhookClient) EditMessage(messageID Snowflake, threadID Snowflake, params EditWebhookMessageParams) (*Message, error) {
+	req, err := newMessageRequest(http.MethodPatch, w.messageURL(messageID, threadID), params, params.Files)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var message Message
+	resp, err := request(w.httpClient(), w.apiURL(), req, &message)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10015 {
+				return nil, ErrWebhookNotFound
+			}
+			if discordErr.code == 10008 {
+				return nil, ErrMessageNotFound
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &message, nil
+}
+
+// DeleteMessage deletes a message previously sent by the webhook. ThreadID can be 0 if the message is not in a thread.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the webhook's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrWebhookNotFound: Returned if the webhook does not exist.
+//   - ErrMessageNotFound: Returned if the message does not exist or was not sent by the webhook.
+func (w *WebhookClient) DeleteMessage(messageID Snowflake, threadID Snowflake) error {
+	req, err := http.NewRequest(http.MethodDelete, w.messageURL(messageID, threadID), nil)
+	if err != nil {
+		return fmt.Errorf("error forming request: %w", err)
+	}
+	resp, err := request(w.httpClient(), w.apiURL(), req, nil)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10015 {
+				return ErrWebhookNotFound
+			}
+			if discordErr.code == 10008 {
+				return ErrMessageNotFound
+			}
+		}
+		return fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusNoContent {
+		return &UnexpectedResponseError{resp}
+	}
+	return nil
+}
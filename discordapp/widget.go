@@ -0,0 +1,152 @@
+package discordapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Guild widget image styles
+const (
+	WidgetStyleShield  = "shield"
+	WidgetStyleBanner1 = "banner1"
+	WidgetStyleBanner2 = "banner2"
+	WidgetStyleBanner3 = "banner3"
+	WidgetStyleBanner4 = "banner4"
+)
+
+// GuildWidgetSettings represents the settings of a guild's widget.
+//
+// ChannelID is the channel invites generated by the widget point to & can be nil.
+type GuildWidgetSettings struct {
+	Enabled   bool       `json:"enabled"`
+	ChannelID *Snowflake `json:"channel_id"`
+}
+
+// GuildWidget represents the public widget of a guild returned by Discord's API.
+type GuildWidget struct {
+	ID            Snowflake           `json:"id"`
+	Name          string              `json:"name"`
+	InstantInvite *string             `json:"instant_invite"`
+	Channels      []Channel           `json:"channels"`
+	Members       []GuildWidgetMember `json:"members"`
+	PresenceCount int                 `json:"presence_count"`
+}
+
+// GuildWidgetMember represents an online member shown in a guild's widget. IDs of widget members are anonymized.
+type GuildWidgetMember struct {
+	ID            Snowflake `json:"id"`
+	Username      string    `json:"username"`
+	Discriminator string    `json:"discriminator"`
+	Avatar        *string   `json:"avatar"`
+	Status        string    `json:"status"`
+	AvatarURL     string    `json:"avatar_url"`
+}
+
+// FetchGuildWidgetSettings fetches the widget settings of the guild with the passed guild ID.
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) FetchGuildWidgetSettings(guildID Snowflake) (*GuildWidgetSettings, error) {
+	req, err := http.NewRequest(http.MethodGet, b.apiURL()+"/guilds/"+guildID.String()+"/widget", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var settings GuildWidgetSettings
+	err = b.guildRequest(req, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// ModifyGuildWidget replaces the widget settings of the guild with the passed guild ID & returns the updated settings.
+//
+// Reason is shown in the guild's audit log & can be "".
+//
+// Possible Errors:
+//   - ErrUnauthorized: Returned if the bot's token is invalid.
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist or the bot is not in the guild.
+//   - ErrMissingPermissions: Returned if the bot does not have the permission to manage the guild.
+func (b *Bot) ModifyGuildWidget(guildID Snowflake, settings GuildWidgetSettings, reason string) (*GuildWidgetSettings, error) {
+	body, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPatch, b.apiURL()+"/guilds/"+guildID.String()+"/widget", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	setAuditLogReason(req, reason)
+	var updated GuildWidgetSettings
+	err = b.guildRequest(req, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// FetchGuildWidget fetches the public widget of the guild with the passed guild ID. No authorization is required.
+//
+// Possible Errors:
+//   - UnexpectedResponseError: Returned if an unexpected response was received.
+//   - DiscordError: Returned if a non-200 response is received & there is an error code in the body.
+//   - ErrGuildNotFound: Returned if the guild does not exist.
+//   - ErrWidgetDisabled: Returned if the guild's widget is disabled.
+func FetchGuildWidget(guildID Snowflake) (*GuildWidget, error) {
+	return fetchGuildWidget(HTTPClient, BaseDiscordAPIURL, guildID)
+}
+
+// FetchGuildWidget fetches the public widget of the guild with the passed guild ID like the FetchGuildWidget function, using the bot's
+// BaseURL & HTTPClient. The bot's token isn't sent.
+//
+// It returns the same errors as the FetchGuildWidget function.
+func (b *Bot) FetchGuildWidget(guildID Snowflake) (*GuildWidget, error) {
+	return fetchGuildWidget(b.httpClient(), b.apiURL(), guildID)
+}
+
+// fetchGuildWidget fetches the public widget of the guild with the passed guild ID from the API at the passed base URL.
+func fetchGuildWidget(client *http.Client, baseURL string, guildID Snowflake) (*GuildWidget, error) {
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/guilds/"+guildID.String()+"/widget.json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error forming request: %w", err)
+	}
+	var widget GuildWidget
+	resp, err := request(client, baseURL, req, &widget)
+	if err != nil {
+		var discordErr *DiscordError
+		if errors.As(err, &discordErr) {
+			if discordErr.code == 10004 {
+				return nil, ErrGuildNotFound
+			}
+			if discordErr.code == 50004 {
+				return nil, ErrWidgetDisabled
+			}
+		}
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.Status != http.StatusOK {
+		return nil, &UnexpectedResponseError{resp}
+	}
+	return &widget, nil
+}
+
+// GuildWidgetImageURL returns the URL of the widget image of the guild with the passed guild ID.
+//
+// Style should be one of the WidgetStyle constants or "" for the default shield style.
+func GuildWidgetImageURL(guildID Snowflake, style string) string {
+	link := BaseDiscordAPIURL + "/guilds/" + guildID.String() + "/widget.png"
+	if style != "" {
+		link += "?style=" + url.QueryEscape(style)
+	}
+	return link
+}
@@ -1,14 +1,11 @@
 package integration
 
 import (
-	"os"
 	"testing"
-
-	"github.com/kodishim/discordapp/discordapp"
 )
 
 func TestNewApplication(t *testing.T) {
-	_, err := discordapp.NewApplication(os.Getenv("TOKEN"), os.Getenv("SECRET"))
+	_, err := NewApplication()
 	if err != nil {
 		t.Fatalf("Error creating new application: %s", err)
 	}
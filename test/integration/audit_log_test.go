@@ -0,0 +1,39 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestFetchGuildAuditLog(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	_, err = bot.FetchGuildAuditLog(envID("GUILD"), discordapp.AuditLogParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("Error fetching audit log: %s", err)
+	}
+	it := bot.AuditLogIterator(envID("GUILD"), discordapp.AuditLogParams{UserID: bot.Application.ID, Limit: 5})
+	var previous discordapp.Snowflake
+	for i := 0; i < 20 && it.Next(); i++ {
+		entry := it.Entry()
+		if entry.UserID == nil || *entry.UserID != bot.Application.ID {
+			t.Fatalf("Expected entry by the bot")
+		}
+		if previous != 0 && entry.ID == previous {
+			t.Fatalf("Expected distinct entries while paging")
+		}
+		previous = entry.ID
+	}
+	if it.Err() != nil {
+		t.Fatalf("Error iterating audit log: %s", it.Err())
+	}
+	_, err = bot.FetchGuildAuditLog(111, discordapp.AuditLogParams{})
+	if err != discordapp.ErrGuildNotFound {
+		t.Fatalf("Expected ErrGuildNotFound: %s", err)
+	}
+}
@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestAutoModerationRule(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	rule, err := bot.CreateAutoModerationRule(envID("GUILD"), discordapp.CreateAutoModerationRuleParams{
+		Name:        "discordapp test",
+		EventType:   discordapp.AutoModerationEventTypeMessageSend,
+		TriggerType: discordapp.AutoModerationTriggerTypeKeyword,
+		TriggerMetadata: &discordapp.AutoModerationTriggerMetadata{
+			KeywordFilter: []string{"discordapptest*"},
+		},
+		Actions: []discordapp.AutoModerationAction{{Type: discordapp.AutoModerationActionTypeBlockMessage}},
+	})
+	if err != nil {
+		t.Fatalf("Error creating auto moderation rule: %s", err)
+	}
+	defer bot.DeleteAutoModerationRule(envID("GUILD"), rule.ID)
+	enabled := false
+	rule, err = bot.ModifyAutoModerationRule(envID("GUILD"), rule.ID, discordapp.ModifyAutoModerationRuleParams{Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("Error modifying auto moderation rule: %s", err)
+	}
+	matches, err := rule.Evaluate("discordapptesting")
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("Expected fetched rule to match locally: %v %s", matches, err)
+	}
+	err = bot.DeleteAutoModerationRule(envID("GUILD"), rule.ID)
+	if err != nil {
+		t.Fatalf("Error deleting auto moderation rule: %s", err)
+	}
+	_, err = bot.FetchAutoModerationRule(envID("GUILD"), rule.ID)
+	if err != discordapp.ErrAutoModerationRuleNotFound {
+		t.Fatalf("Expected ErrAutoModerationRuleNotFound: %s", err)
+	}
+}
@@ -1,21 +1,40 @@
 package integration_test
 
 import (
+	"log"
 	"os"
 	"testing"
 
 	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
 )
 
+// envID returns the ID stored in the env variable with the passed name.
+func envID(variableName string) discordapp.Snowflake {
+	id, err := discordapp.ParseSnowflake(os.Getenv(variableName))
+	if err != nil {
+		log.Fatalf("%s env variable is not a valid ID: %s", variableName, err)
+	}
+	return id
+}
+
+// requireLive skips tests of endpoints the fake server used without a .env file does not emulate. Offline, only the application, guild,
+// guild member & OAuth2 tests in bot_test.go & application_test.go run; the tests calling requireLive only run against Discord's API.
+func requireLive(t *testing.T) {
+	if integration.Offline {
+		t.Skip("requires a live Discord application, see readme")
+	}
+}
+
 func TestNewBot(t *testing.T) {
-	_, err := discordapp.NewBot(os.Getenv("TOKEN"))
+	_, err := integration.NewBot()
 	if err != nil {
 		t.Fatalf("Error creating new bot: %s", err)
 	}
 }
 
 func TestFetchApplicationInfo(t *testing.T) {
-	bot, err := discordapp.NewBot(os.Getenv("TOKEN"))
+	bot, err := integration.NewBot()
 	if err != nil {
 		t.Fatalf("Error creating new bot: %s", err)
 	}
@@ -26,76 +45,102 @@ func TestFetchApplicationInfo(t *testing.T) {
 }
 
 func TestFetchGuildPreview(t *testing.T) {
-	bot, err := discordapp.NewBot(os.Getenv("TOKEN"))
+	bot, err := integration.NewBot()
 	if err != nil {
 		t.Fatalf("Error creating new bot: %s", err)
 	}
-	_, err = bot.FetchGuildPreview(os.Getenv("GUILD"))
+	_, err = bot.FetchGuildPreview(envID("GUILD"))
 	if err != nil {
 		t.Fatalf("Error fetching guild preview: %s", err)
 	}
-	_, err = bot.FetchGuildPreview("111")
+	_, err = bot.FetchGuildPreview(111)
 	if err != discordapp.ErrGuildNotFound {
 		t.Fatalf("Expected ErrGuildNotFound: %s", err)
 	}
 }
 
 func TestFetchGuild(t *testing.T) {
-	bot, err := discordapp.NewBot(os.Getenv("TOKEN"))
+	bot, err := integration.NewBot()
 	if err != nil {
 		t.Fatalf("Error creating new bot: %s", err)
 	}
-	_, err = bot.FetchGuild(os.Getenv("GUILD"))
+	guild, err := bot.FetchGuild(envID("GUILD"))
 	if err != nil {
 		t.Fatalf("Error fetching guild preview: %s", err)
 	}
-	_, err = bot.FetchGuild("111")
+	if guild.ApproximateMemberCount == 0 {
+		t.Fatalf("Expected approximate member count to be populated")
+	}
+	_, err = bot.FetchGuild(111)
+	if err != discordapp.ErrGuildNotFound {
+		t.Fatalf("Expected ErrGuildNotFound: %s", err)
+	}
+}
+
+func TestModifyGuild(t *testing.T) {
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	guild, err := bot.FetchGuild(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error fetching guild: %s", err)
+	}
+	name := guild.Name
+	modified, err := bot.ModifyGuild(envID("GUILD"), discordapp.ModifyGuildParams{Name: &name})
+	if err != nil {
+		t.Fatalf("Error modifying guild: %s", err)
+	}
+	if modified.Name != name {
+		t.Fatalf("Expected unchanged name, got: %s", modified.Name)
+	}
+	_, err = bot.ModifyGuild(111, discordapp.ModifyGuildParams{Name: &name})
 	if err != discordapp.ErrGuildNotFound {
 		t.Fatalf("Expected ErrGuildNotFound: %s", err)
 	}
 }
 
 func TestFetchGuildMember(t *testing.T) {
-	bot, err := discordapp.NewBot(os.Getenv("TOKEN"))
+	bot, err := integration.NewBot()
 	if err != nil {
 		t.Fatalf("Error creating new bot: %s", err)
 	}
-	_, err = bot.FetchGuildMember(os.Getenv("GUILD"), os.Getenv("MEMBER"))
+	_, err = bot.FetchGuildMember(envID("GUILD"), envID("MEMBER"))
 	if err != nil {
 		t.Fatalf("Error fetching member: %s", err)
 	}
-	_, err = bot.FetchGuildMember(("111"), os.Getenv("MEMBER"))
+	_, err = bot.FetchGuildMember(111, envID("MEMBER"))
 	if err != discordapp.ErrGuildNotFound {
 		t.Fatalf("Error ErrGuildNotFound: %s", err)
 	}
-	_, err = bot.FetchGuildMember(os.Getenv("GUILD"), "111")
+	_, err = bot.FetchGuildMember(envID("GUILD"), 111)
 	if err != discordapp.ErrUserNotFound {
 		t.Fatalf("Expected ErrUserNotFound: %s", err)
 	}
 }
 
 func TestAddMemberToGuild(t *testing.T) {
-	bot, err := discordapp.NewBot(os.Getenv("TOKEN"))
+	bot, err := integration.NewBot()
 	if err != nil {
 		t.Fatalf("Error creating new bot: %s", err)
 	}
-	err = bot.AddMemberToGuild("111", os.Getenv("AUTHORIZED_USER"), os.Getenv("GUILD"))
+	err = bot.AddMemberToGuild("111", envID("AUTHORIZED_USER"), envID("GUILD"))
 	if err != discordapp.ErrInvalidAccessToken {
 		t.Fatalf("Expected ErrInvalidAccessToken: %s", err)
 	}
-	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), "111", os.Getenv("GUILD"))
+	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), 111, envID("GUILD"))
 	if err != discordapp.ErrInvalidAccessToken {
 		t.Fatalf("Expected ErrInvalidAccessToken: %s", err)
 	}
-	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), os.Getenv("AUTHORIZED_USER"), "111")
+	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), envID("AUTHORIZED_USER"), 111)
 	if err != discordapp.ErrGuildNotFound {
 		t.Fatalf("Error ErrGuildNotFound: %s", err)
 	}
-	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), os.Getenv("AUTHORIZED_USER"), os.Getenv("GUILD"))
+	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), envID("AUTHORIZED_USER"), envID("GUILD"))
 	if err != nil {
 		t.Fatalf("Error adding user to guild: %s", err)
 	}
-	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), os.Getenv("AUTHORIZED_USER"), os.Getenv("GUILD"))
+	err = bot.AddMemberToGuild(os.Getenv("ACCESS_TOKEN"), envID("AUTHORIZED_USER"), envID("GUILD"))
 	if err != discordapp.ErrAlreadyInGuild {
 		t.Fatalf("Expected ErrAlreadyInGuild: %s", err)
 	}
@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestGuildChannels(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	category, err := bot.CreateGuildChannel(envID("GUILD"), discordapp.CreateGuildChannelParams{
+		Name: "discordapp-test",
+		Type: discordapp.ChannelTypeGuildCategory,
+		PermissionOverwrites: []discordapp.PermissionOverwrite{
+			{ID: envID("GUILD"), Type: discordapp.OverwriteTypeRole, Deny: discordapp.PermissionViewChannel},
+			{ID: bot.Application.ID, Type: discordapp.OverwriteTypeMember, Allow: discordapp.PermissionViewChannel | discordapp.PermissionManageChannels | discordapp.PermissionManageRoles},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating category: %s", err)
+	}
+	defer bot.DeleteChannel(category.ID)
+	channel, err := bot.CreateGuildChannel(envID("GUILD"), discordapp.CreateGuildChannelParams{
+		Name:     "discordapp-test",
+		Type:     discordapp.ChannelTypeGuildText,
+		ParentID: category.ID,
+	})
+	if err != nil {
+		t.Fatalf("Error creating channel: %s", err)
+	}
+	defer bot.DeleteChannel(channel.ID)
+	err = bot.EditChannelPermissions(channel.ID, discordapp.PermissionOverwrite{
+		ID:    envID("MEMBER"),
+		Type:  discordapp.OverwriteTypeMember,
+		Allow: discordapp.PermissionViewChannel | discordapp.PermissionSendMessages,
+	})
+	if err != nil {
+		t.Fatalf("Error editing channel permissions: %s", err)
+	}
+	topic := "discordapp test"
+	channel, err = bot.ModifyChannel(channel.ID, discordapp.ModifyChannelParams{Topic: &topic})
+	if err != nil {
+		t.Fatalf("Error modifying channel: %s", err)
+	}
+	if channel.Topic == nil || *channel.Topic != topic {
+		t.Fatalf("Expected modified topic")
+	}
+	err = bot.DeleteChannelPermission(channel.ID, envID("MEMBER"))
+	if err != nil {
+		t.Fatalf("Error deleting channel permission: %s", err)
+	}
+	channels, err := bot.ListGuildChannels(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error listing guild channels: %s", err)
+	}
+	found := false
+	for _, c := range channels {
+		if c.ID == channel.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected created channel in guild channels")
+	}
+	_, err = bot.DeleteChannel(channel.ID)
+	if err != nil {
+		t.Fatalf("Error deleting channel: %s", err)
+	}
+	_, err = bot.FetchChannel(channel.ID)
+	if err != discordapp.ErrChannelNotFound {
+		t.Fatalf("Expected ErrChannelNotFound: %s", err)
+	}
+}
@@ -0,0 +1,24 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestFetchGuildCommandPermissions(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	_, err = bot.FetchGuildCommandPermissions(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error fetching command permissions: %s", err)
+	}
+	_, err = bot.FetchCommandPermissions(envID("GUILD"), 111)
+	if err != discordapp.ErrCommandNotFound {
+		t.Fatalf("Expected ErrCommandNotFound: %s", err)
+	}
+}
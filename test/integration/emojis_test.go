@@ -0,0 +1,67 @@
+package integration_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+var testPNG, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR4nGP4z8DwHwAFAAH/iZk9HQAAAABJRU5ErkJggg==")
+
+func TestGuildEmoji(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	emoji, err := bot.CreateGuildEmoji(envID("GUILD"), "discordapp_test", testPNG, nil)
+	if err != nil {
+		t.Fatalf("Error creating emoji: %s", err)
+	}
+	name := "discordapp_test_edited"
+	emoji, err = bot.ModifyGuildEmoji(envID("GUILD"), emoji.ID, discordapp.ModifyGuildEmojiParams{Name: &name})
+	if err != nil {
+		t.Fatalf("Error modifying emoji: %s", err)
+	}
+	if emoji.Name != name {
+		t.Fatalf("Expected modified name, got: %s", emoji.Name)
+	}
+	err = bot.DeleteGuildEmoji(envID("GUILD"), emoji.ID)
+	if err != nil {
+		t.Fatalf("Error deleting emoji: %s", err)
+	}
+	_, err = bot.FetchGuildEmoji(envID("GUILD"), emoji.ID)
+	if err != discordapp.ErrEmojiNotFound {
+		t.Fatalf("Expected ErrEmojiNotFound: %s", err)
+	}
+}
+
+func TestGuildSticker(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	sticker, err := bot.CreateGuildSticker(envID("GUILD"), discordapp.CreateGuildStickerParams{
+		Name:        "discordapp test",
+		Description: "discordapp test sticker",
+		Tags:        "robot",
+		File:        testPNG,
+	})
+	if err != nil {
+		t.Fatalf("Error creating sticker: %s", err)
+	}
+	if sticker.FormatType != discordapp.StickerFormatPNG {
+		t.Fatalf("Expected PNG sticker, got format: %d", sticker.FormatType)
+	}
+	err = bot.DeleteGuildSticker(envID("GUILD"), sticker.ID)
+	if err != nil {
+		t.Fatalf("Error deleting sticker: %s", err)
+	}
+	_, err = bot.FetchGuildSticker(envID("GUILD"), sticker.ID)
+	if err != discordapp.ErrStickerNotFound {
+		t.Fatalf("Expected ErrStickerNotFound: %s", err)
+	}
+}
@@ -5,20 +5,66 @@ import (
 	"os"
 
 	"github.com/joho/godotenv"
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/discordapp/discordtest"
 )
 
+// Offline is true when no .env file is present, in which case the tests run against a discordtest.Server instead of Discord's API.
+var Offline bool
+
+// BaseURL is the URL of the discordtest.Server when Offline & "" otherwise.
+var BaseURL string
+
+// NewBot creates a bot using the TOKEN env variable that sends its requests to BaseURL.
+func NewBot() (*discordapp.Bot, error) {
+	return discordapp.NewBotWithConfig(os.Getenv("TOKEN"), discordapp.BotConfig{BaseURL: BaseURL})
+}
+
+// NewApplication creates an application using the TOKEN & SECRET env variables that sends its requests to BaseURL.
+func NewApplication() (*discordapp.Application, error) {
+	bot, err := NewBot()
+	if err != nil {
+		return nil, err
+	}
+	return &discordapp.Application{Bot: bot, Secret: discordapp.Secret(os.Getenv("SECRET"))}, nil
+}
+
 func init() {
 	err := godotenv.Load()
 	if err != nil {
-		log.Fatalf("error loading .env: %s", err)
+		log.Printf("error loading .env, running against a fake server: %s", err)
+		startFakeServer()
+		return
 	}
 	checkVariable := func(variableName string) {
 		if os.Getenv(variableName) == "" {
 			log.Fatalf("%s env variable is missing", variableName)
 		}
 	}
-	variablesToCheck := []string{"TOKEN", "SECRET", "GUILD", "MEMBER", "ACCESS_TOKEN", "AUTHORIZED_USER"}
+	variablesToCheck := []string{"TOKEN", "SECRET", "GUILD", "MEMBER", "ACCESS_TOKEN", "AUTHORIZED_USER", "CHANNEL"}
 	for _, v := range variablesToCheck {
 		checkVariable(v)
 	}
 }
+
+// startFakeServer starts a discordtest.Server seeded with the objects the tests expect & sets the env variables to match it.
+func startFakeServer() {
+	Offline = true
+	server := discordtest.NewServer("test-token", "test-secret", discordapp.ApplicationInfo{ID: 1000, Name: "discordapp"})
+	server.AddGuild(discordapp.Guild{ID: 2000, Name: "discordapp test guild", OwnerID: 3000})
+	server.AddMember(2000, discordapp.Member{User: discordapp.MemberUser{ID: 3000, Username: "member"}})
+	server.AddUser(discordapp.AuthorizedUser{ID: 4000, Username: "authorized"}, "test-access-token")
+	BaseURL = server.URL
+	variables := map[string]string{
+		"TOKEN":           "test-token",
+		"SECRET":          "test-secret",
+		"GUILD":           "2000",
+		"MEMBER":          "3000",
+		"ACCESS_TOKEN":    "test-access-token",
+		"AUTHORIZED_USER": "4000",
+		"CHANNEL":         "5000",
+	}
+	for name, value := range variables {
+		os.Setenv(name, value)
+	}
+}
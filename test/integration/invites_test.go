@@ -0,0 +1,49 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestInvite(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	maxAge := 60
+	invite, err := bot.CreateChannelInvite(envID("CHANNEL"), discordapp.CreateInviteParams{MaxAge: &maxAge, MaxUses: 1, Unique: true})
+	if err != nil {
+		t.Fatalf("Error creating invite: %s", err)
+	}
+	fetched, err := bot.FetchInvite(invite.Code, true, true)
+	if err != nil {
+		t.Fatalf("Error fetching invite: %s", err)
+	}
+	if fetched.ExpiresAt == nil {
+		t.Fatalf("Expected invite to have an expiration")
+	}
+	invites, err := bot.ListGuildInvites(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error listing guild invites: %s", err)
+	}
+	found := false
+	for _, i := range invites {
+		if i.Code == invite.Code {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected created invite in guild invites")
+	}
+	_, err = bot.DeleteInvite(invite.Code)
+	if err != nil {
+		t.Fatalf("Error deleting invite: %s", err)
+	}
+	_, err = bot.FetchInvite(invite.Code, false, false)
+	if err != discordapp.ErrInviteNotFound {
+		t.Fatalf("Expected ErrInviteNotFound: %s", err)
+	}
+}
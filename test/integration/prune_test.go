@@ -0,0 +1,32 @@
+package integration_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestGetGuildPruneCount(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	count, err := bot.GetGuildPruneCount(envID("GUILD"), 30, nil)
+	if err != nil {
+		t.Fatalf("Error getting guild prune count: %s", err)
+	}
+	if count < 0 {
+		t.Fatalf("Expected non-negative prune count: %d", count)
+	}
+	_, err = bot.GetGuildPruneCount(envID("GUILD"), 31, nil)
+	if !errors.Is(err, discordapp.ErrInvalidPruneDays) {
+		t.Fatalf("Expected ErrInvalidPruneDays: %s", err)
+	}
+	_, err = bot.GetGuildPruneCount(111, 7, nil)
+	if err != discordapp.ErrGuildNotFound {
+		t.Fatalf("Expected ErrGuildNotFound: %s", err)
+	}
+}
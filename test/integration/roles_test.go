@@ -0,0 +1,83 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestGuildRoles(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	role, err := bot.CreateGuildRole(envID("GUILD"), discordapp.CreateGuildRoleParams{Name: "Test Role", Color: 0xff0000})
+	if err != nil {
+		t.Fatalf("Error creating guild role: %s", err)
+	}
+	hoist := true
+	role, err = bot.ModifyGuildRole(envID("GUILD"), role.ID, discordapp.ModifyGuildRoleParams{Hoist: &hoist})
+	if err != nil {
+		t.Fatalf("Error modifying guild role: %s", err)
+	}
+	if !role.Hoist {
+		t.Fatalf("Expected role to be hoisted")
+	}
+	roles, err := bot.ListGuildRoles(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error listing guild roles: %s", err)
+	}
+	found := false
+	for _, r := range roles {
+		found = found || r.ID == role.ID
+	}
+	if !found {
+		t.Fatalf("Expected created role to be listed")
+	}
+	err = bot.DeleteGuildRole(envID("GUILD"), role.ID)
+	if err != nil {
+		t.Fatalf("Error deleting guild role: %s", err)
+	}
+	err = bot.DeleteGuildRole(envID("GUILD"), role.ID)
+	if err != discordapp.ErrRoleNotFound {
+		t.Fatalf("Expected ErrRoleNotFound: %s", err)
+	}
+}
+
+func TestGuildLayout(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	layout, err := bot.ExportGuildLayout(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error exporting guild layout: %s", err)
+	}
+	layout.Roles = append([]discordapp.LayoutRole{{Name: "Layout Role"}}, layout.Roles...)
+	plan, err := bot.PlanGuildLayout(envID("GUILD"), layout)
+	if err != nil {
+		t.Fatalf("Error planning guild layout: %s", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != discordapp.LayoutActionCreate {
+		t.Fatalf("Expected a single role creation:\n%s", plan)
+	}
+	err = bot.ApplyGuildLayoutPlan(envID("GUILD"), plan)
+	if err != nil {
+		t.Fatalf("Error applying guild layout plan: %s", err)
+	}
+	layout.Roles = layout.Roles[1:]
+	plan, err = bot.PlanGuildLayout(envID("GUILD"), layout)
+	if err != nil {
+		t.Fatalf("Error planning guild layout: %s", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != discordapp.LayoutActionDelete {
+		t.Fatalf("Expected a single role deletion:\n%s", plan)
+	}
+	err = bot.ApplyGuildLayoutPlan(envID("GUILD"), plan)
+	if err != nil {
+		t.Fatalf("Error applying guild layout plan: %s", err)
+	}
+}
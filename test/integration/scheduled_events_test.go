@@ -0,0 +1,60 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestScheduledEvent(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	start := time.Now().Add(24 * time.Hour)
+	end := start.Add(time.Hour)
+	event, err := bot.CreateScheduledEvent(envID("GUILD"), discordapp.CreateScheduledEventParams{
+		Name:               "discordapp test",
+		EntityType:         discordapp.ScheduledEventEntityTypeExternal,
+		EntityMetadata:     &discordapp.ScheduledEventEntityMetadata{Location: "https://example.com"},
+		ScheduledStartTime: start,
+		ScheduledEndTime:   &end,
+	})
+	if err != nil {
+		t.Fatalf("Error creating scheduled event: %s", err)
+	}
+	defer bot.DeleteScheduledEvent(envID("GUILD"), event.ID)
+	completed := discordapp.ScheduledEventStatusCompleted
+	_, err = bot.ModifyScheduledEvent(envID("GUILD"), event.ID, discordapp.ModifyScheduledEventParams{Status: &completed})
+	if err == nil {
+		t.Fatalf("Expected error completing a scheduled event that is not active")
+	}
+	events, err := bot.ListScheduledEvents(envID("GUILD"), true)
+	if err != nil {
+		t.Fatalf("Error listing scheduled events: %s", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.ID == event.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected created event in scheduled events")
+	}
+	_, err = bot.ListScheduledEventUsers(envID("GUILD"), event.ID, 10, true, 0, 0)
+	if err != nil {
+		t.Fatalf("Error listing scheduled event users: %s", err)
+	}
+	err = bot.DeleteScheduledEvent(envID("GUILD"), event.ID)
+	if err != nil {
+		t.Fatalf("Error deleting scheduled event: %s", err)
+	}
+	_, err = bot.FetchScheduledEvent(envID("GUILD"), event.ID, false)
+	if err != discordapp.ErrScheduledEventNotFound {
+		t.Fatalf("Expected ErrScheduledEventNotFound: %s", err)
+	}
+}
@@ -0,0 +1,57 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestGuildTemplates(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	templates, err := bot.ListGuildTemplates(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error listing guild templates: %s", err)
+	}
+	for _, template := range templates {
+		_, err = bot.DeleteGuildTemplate(envID("GUILD"), template.Code)
+		if err != nil {
+			t.Fatalf("Error deleting existing guild template: %s", err)
+		}
+	}
+	template, err := bot.CreateGuildTemplate(envID("GUILD"), "Test Template", "")
+	if err != nil {
+		t.Fatalf("Error creating guild template: %s", err)
+	}
+	if template.SerializedSourceGuild == nil || len(template.SerializedSourceGuild.Roles) == 0 {
+		t.Fatalf("Expected serialized source guild with roles")
+	}
+	name := "Renamed Template"
+	template, err = bot.ModifyGuildTemplate(envID("GUILD"), template.Code, discordapp.ModifyGuildTemplateParams{Name: &name})
+	if err != nil {
+		t.Fatalf("Error modifying guild template: %s", err)
+	}
+	if template.Name != name {
+		t.Fatalf("Expected template to be renamed")
+	}
+	_, err = bot.SyncGuildTemplate(envID("GUILD"), template.Code)
+	if err != nil {
+		t.Fatalf("Error syncing guild template: %s", err)
+	}
+	_, err = bot.FetchGuildTemplate(template.Code)
+	if err != nil {
+		t.Fatalf("Error fetching guild template: %s", err)
+	}
+	_, err = bot.DeleteGuildTemplate(envID("GUILD"), template.Code)
+	if err != nil {
+		t.Fatalf("Error deleting guild template: %s", err)
+	}
+	_, err = bot.FetchGuildTemplate(template.Code)
+	if err != discordapp.ErrGuildTemplateNotFound {
+		t.Fatalf("Expected ErrGuildTemplateNotFound: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestThread(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	thread, err := bot.StartThreadWithoutMessage(envID("CHANNEL"), discordapp.StartThreadParams{
+		Name:                "discordapp test",
+		Type:                discordapp.ChannelTypePublicThread,
+		AutoArchiveDuration: discordapp.ThreadAutoArchiveDurationHour,
+	})
+	if err != nil {
+		t.Fatalf("Error starting thread: %s", err)
+	}
+	defer bot.DeleteChannel(thread.ID)
+	err = bot.AddThreadMember(thread.ID, envID("MEMBER"))
+	if err != nil {
+		t.Fatalf("Error adding thread member: %s", err)
+	}
+	members, err := bot.ListThreadMembers(thread.ID, true, 0, 0)
+	if err != nil {
+		t.Fatalf("Error listing thread members: %s", err)
+	}
+	found := false
+	for _, m := range members {
+		if m.UserID == envID("MEMBER") {
+			found = true
+			if m.Member == nil {
+				t.Fatalf("Expected guild member to be populated")
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("Expected added member in thread members")
+	}
+	err = bot.RemoveThreadMember(thread.ID, envID("MEMBER"))
+	if err != nil {
+		t.Fatalf("Error removing thread member: %s", err)
+	}
+	active, err := bot.ListActiveGuildThreads(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error listing active threads: %s", err)
+	}
+	found = false
+	for _, th := range active.Threads {
+		if th.ID == thread.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected started thread in active threads")
+	}
+	archived := true
+	_, err = bot.ModifyChannel(thread.ID, discordapp.ModifyChannelParams{Archived: &archived})
+	if err != nil {
+		t.Fatalf("Error archiving thread: %s", err)
+	}
+	_, err = bot.ListPublicArchivedThreads(envID("CHANNEL"), nil, 10)
+	if err != nil {
+		t.Fatalf("Error listing archived threads: %s", err)
+	}
+}
@@ -0,0 +1,55 @@
+package integration_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestWebhook(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	webhook, err := bot.CreateWebhook(envID("CHANNEL"), "discordapp test", nil)
+	if err != nil {
+		t.Fatalf("Error creating webhook: %s", err)
+	}
+	defer bot.DeleteWebhook(webhook.ID)
+	client := webhook.Client()
+	message, err := client.Execute(discordapp.ExecuteWebhookParams{
+		Content:  "discordapp test",
+		Username: "discordapp",
+		Files:    []discordapp.File{{Name: "test.txt", Reader: strings.NewReader("test")}},
+	}, true, 0)
+	if err != nil {
+		t.Fatalf("Error executing webhook: %s", err)
+	}
+	content := "discordapp test edited"
+	message, err = client.EditMessage(message.ID, 0, discordapp.EditWebhookMessageParams{Content: &content})
+	if err != nil {
+		t.Fatalf("Error editing webhook message: %s", err)
+	}
+	if message.Content != content {
+		t.Fatalf("Expected edited content, got: %s", message.Content)
+	}
+	err = client.DeleteMessage(message.ID, 0)
+	if err != nil {
+		t.Fatalf("Error deleting webhook message: %s", err)
+	}
+	_, err = client.FetchMessage(message.ID, 0)
+	if err != discordapp.ErrMessageNotFound {
+		t.Fatalf("Expected ErrMessageNotFound: %s", err)
+	}
+	err = bot.DeleteWebhook(webhook.ID)
+	if err != nil {
+		t.Fatalf("Error deleting webhook: %s", err)
+	}
+	_, err = bot.FetchWebhook(webhook.ID)
+	if err != discordapp.ErrWebhookNotFound {
+		t.Fatalf("Expected ErrWebhookNotFound: %s", err)
+	}
+}
@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/test/integration"
+)
+
+func TestGuildWidget(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	settings, err := bot.FetchGuildWidgetSettings(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error fetching guild widget settings: %s", err)
+	}
+	channelID := envID("CHANNEL")
+	updated, err := bot.ModifyGuildWidget(envID("GUILD"), discordapp.GuildWidgetSettings{Enabled: true, ChannelID: &channelID}, "Testing widget")
+	if err != nil {
+		t.Fatalf("Error modifying guild widget: %s", err)
+	}
+	if !updated.Enabled || updated.ChannelID == nil || *updated.ChannelID != channelID {
+		t.Fatalf("Expected widget to be enabled with the passed channel")
+	}
+	widget, err := discordapp.FetchGuildWidget(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error fetching guild widget: %s", err)
+	}
+	if widget.ID != envID("GUILD") {
+		t.Fatalf("Expected widget of the guild")
+	}
+	_, err = bot.ModifyGuildWidget(envID("GUILD"), *settings, "")
+	if err != nil {
+		t.Fatalf("Error restoring guild widget: %s", err)
+	}
+	_, err = bot.FetchGuildWidgetSettings(111)
+	if err != discordapp.ErrGuildNotFound {
+		t.Fatalf("Expected ErrGuildNotFound: %s", err)
+	}
+}
+
+func TestFetchOnboarding(t *testing.T) {
+	requireLive(t)
+	bot, err := integration.NewBot()
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	onboarding, err := bot.FetchOnboarding(envID("GUILD"))
+	if err != nil {
+		t.Fatalf("Error fetching onboarding: %s", err)
+	}
+	if onboarding.GuildID != envID("GUILD") {
+		t.Fatalf("Expected onboarding of the guild")
+	}
+	_, err = bot.FetchOnboarding(111)
+	if err != discordapp.ErrGuildNotFound {
+		t.Fatalf("Expected ErrGuildNotFound: %s", err)
+	}
+}
@@ -0,0 +1,62 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestAuditLogEntryChanges(t *testing.T) {
+	body := `{
+		"id": "1181111111111111111",
+		"user_id": "1182222222222222222",
+		"target_id": "1183333333333333333",
+		"action_type": 24,
+		"reason": "discordapp test",
+		"changes": [
+			{"key": "nick", "old_value": "old", "new_value": "new"},
+			{"key": "$add", "new_value": [{"id": "1184444444444444444", "name": "role"}]},
+			{"key": "permissions", "old_value": "0", "new_value": "1024"}
+		]
+	}`
+	var entry discordapp.AuditLogEntry
+	err := json.Unmarshal([]byte(body), &entry)
+	if err != nil {
+		t.Fatalf("Error unmarshaling entry: %s", err)
+	}
+	if entry.ActionType != discordapp.AuditLogActionMemberUpdate || entry.ActionType.String() != "MEMBER_UPDATE" {
+		t.Fatalf("Expected MEMBER_UPDATE, got %s", entry.ActionType)
+	}
+	changes := entry.ChangesByKey()
+	var oldNick, newNick string
+	nick := changes[discordapp.AuditLogChangeKeyNick]
+	err = nick.Decode(&oldNick, &newNick)
+	if err != nil {
+		t.Fatalf("Error decoding nick change: %s", err)
+	}
+	if oldNick != "old" || newNick != "new" {
+		t.Fatalf("Expected old -> new, got %s -> %s", oldNick, newNick)
+	}
+	var added []discordapp.Role
+	roleAdd := changes[discordapp.AuditLogChangeKeyRoleAdd]
+	err = roleAdd.Decode(nil, &added)
+	if err != nil {
+		t.Fatalf("Error decoding role change: %s", err)
+	}
+	if len(added) != 1 || added[0].Name != "role" {
+		t.Fatalf("Expected one added role, got %v", added)
+	}
+	var newPermissions discordapp.Permissions
+	permissions := changes[discordapp.AuditLogChangeKeyPermissions]
+	err = permissions.Decode(nil, &newPermissions)
+	if err != nil {
+		t.Fatalf("Error decoding permissions change: %s", err)
+	}
+	if !newPermissions.Has(discordapp.PermissionViewChannel) {
+		t.Fatalf("Expected new permissions to have view channel")
+	}
+	if discordapp.AuditLogActionType(999).String() != "UNKNOWN_999" {
+		t.Fatalf("Expected unknown action type name")
+	}
+}
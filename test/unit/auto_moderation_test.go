@@ -0,0 +1,76 @@
+package unit_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestAutoModerationRuleEvaluate(t *testing.T) {
+	rule := discordapp.AutoModerationRule{
+		TriggerType: discordapp.AutoModerationTriggerTypeKeyword,
+		TriggerMetadata: discordapp.AutoModerationTriggerMetadata{
+			KeywordFilter: []string{"cat", "dog*", "*fish", "*bird*"},
+			RegexPatterns: []string{`free\s+nitro`},
+			AllowList:     []string{"catfish", "dogma"},
+		},
+	}
+	tests := []struct {
+		content string
+		matches []string
+	}{
+		{"I have a Cat", []string{"Cat"}},
+		{"concatenate", nil},
+		{"doggo and dogs", []string{"doggo", "dogs"}},
+		{"dogma", nil},
+		{"swordfish", []string{"swordfish"}},
+		{"catfish", nil},
+		{"blackbirds", []string{"blackbirds"}},
+		{"get FREE   nitro now", []string{"FREE   nitro"}},
+	}
+	for _, test := range tests {
+		matches, err := rule.Evaluate(test.content)
+		if err != nil {
+			t.Fatalf("Error evaluating %q: %s", test.content, err)
+		}
+		if len(matches) != len(test.matches) {
+			t.Fatalf("Expected %d matches for %q, got %v", len(test.matches), test.content, matches)
+		}
+		for i, match := range matches {
+			if match.Content != test.matches[i] {
+				t.Fatalf("Expected match %q for %q, got %q", test.matches[i], test.content, match.Content)
+			}
+		}
+	}
+	rule.TriggerMetadata = discordapp.AutoModerationTriggerMetadata{KeywordFilter: []string{"*é*"}, RegexPatterns: []string{`x*`}}
+	matches, err := rule.Evaluate("café")
+	if err != nil || len(matches) != 1 || matches[0].Content != "café" {
+		t.Fatalf("Expected one match for a multi-byte keyword & no empty regex matches, got %v: %v", matches, err)
+	}
+	rule.TriggerMetadata.KeywordFilter = []string{"*"}
+	_, err = rule.Evaluate("anything")
+	if err == nil {
+		t.Fatalf("Expected error for an empty keyword")
+	}
+	rule.TriggerType = discordapp.AutoModerationTriggerTypeSpam
+	_, err = rule.Evaluate("anything")
+	if !errors.Is(err, discordapp.ErrUnsupportedTriggerType) {
+		t.Fatalf("Expected ErrUnsupportedTriggerType: %s", err)
+	}
+}
+
+func TestAutoModerationRuleNotFound(t *testing.T) {
+	base, _, _ := interactionServer(t, http.StatusNotFound, `{"code": 10066, "message": "Unknown auto moderation rule"}`)
+	bot := &discordapp.Bot{Token: "token", BaseURL: base}
+	_, err := bot.FetchAutoModerationRule(2000, 4000)
+	if err != discordapp.ErrAutoModerationRuleNotFound {
+		t.Fatalf("Expected ErrAutoModerationRuleNotFound: %s", err)
+	}
+	bot.BaseURL, _, _ = interactionServer(t, http.StatusNotFound, `{"code": 10003, "message": "Unknown Channel"}`)
+	_, err = bot.FetchAutoModerationRule(2000, 4000)
+	if err == discordapp.ErrAutoModerationRuleNotFound {
+		t.Fatalf("Expected other unknown resources not to be reported as a missing rule")
+	}
+}
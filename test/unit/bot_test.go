@@ -0,0 +1,82 @@
+package unit_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestNewApplicationWithID(t *testing.T) {
+	base, last, _ := interactionServer(t, http.StatusOK, `{"items": []}`)
+	app := discordapp.NewApplicationWithID("token", "secret", 1000, "verify-key")
+	app.Bot.BaseURL = base
+	link := app.CreateAuthLink("https://example.com", "", []string{discordapp.ScopeIdentify})
+	if !strings.HasPrefix(link, discordapp.BaseDiscordAPIURL+"/oauth2/authorize?client_id=1000&") || last.Method != "" {
+		t.Fatalf("Expected auth link to Discord to be created without a request, got %s", link)
+	}
+	_, err := app.Bot.ListApplicationEmojis()
+	if err != nil {
+		t.Fatalf("Error listing application emojis: %s", err)
+	}
+	if last.URL.Path != "/applications/1000/emojis" {
+		t.Fatalf("Expected request to use the passed application ID, got %s", last.URL.Path)
+	}
+	if app.Bot.Application != nil || app.Bot.VerifyKey != "verify-key" {
+		t.Fatalf("Expected application info to be left unloaded")
+	}
+}
+
+func TestBotValidate(t *testing.T) {
+	server := useServer(t)
+	bot := discordapp.NewBotWithApplicationID("test-token", 1000, "")
+	bot.BaseURL = server.URL
+	err := bot.Validate()
+	if err != nil {
+		t.Fatalf("Error validating bot: %s", err)
+	}
+	if bot.Application == nil || bot.Application.Name != "discordapp" {
+		t.Fatalf("Expected application info to be loaded, got %+v", bot.Application)
+	}
+	mismatched := discordapp.NewBotWithApplicationID("test-token", 999, "")
+	mismatched.BaseURL = server.URL
+	err = mismatched.Validate()
+	if !errors.Is(err, discordapp.ErrApplicationMismatch) {
+		t.Fatalf("Expected ErrApplicationMismatch, got %v", err)
+	}
+	invalid := discordapp.NewApplicationWithID("invalid-token", "secret", 1000, "")
+	invalid.Bot.BaseURL = server.URL
+	err = invalid.Validate()
+	if !errors.Is(err, discordapp.ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized, got %v", err)
+	}
+
+	lazy := &discordapp.Bot{Token: "test-token", BaseURL: server.URL}
+	useHooks(t, func(info discordapp.RequestInfo) func(discordapp.RequestInfo) {
+		_ = lazy.String()
+		return nil
+	})
+	application, err := lazy.LoadApplication()
+	if err != nil {
+		t.Fatalf("Error loading application info: %s", err)
+	}
+	if application.ID != 1000 || lazy.ApplicationID != 1000 || lazy.Application != application {
+		t.Fatalf("Expected application info to be stored on the bot, got %+v", lazy)
+	}
+	_, err = mismatched.LoadApplication()
+	if !errors.Is(err, discordapp.ErrApplicationMismatch) || mismatched.Application != nil {
+		t.Fatalf("Expected LoadApplication to reject another application's token, got %v", err)
+	}
+	invalid = &discordapp.Application{Bot: &discordapp.Bot{Token: "invalid-token", BaseURL: server.URL}}
+	_, err = invalid.LoadAuthLink("https://example.com", "", nil)
+	if !errors.Is(err, discordapp.ErrUnauthorized) {
+		t.Fatalf("Expected auth link to need the application ID, got %v", err)
+	}
+	app := &discordapp.Application{Bot: &discordapp.Bot{Token: "test-token", BaseURL: server.URL}}
+	link, err := app.LoadAuthLink("https://example.com", "", nil)
+	if err != nil || !strings.HasPrefix(link, discordapp.BaseDiscordAPIURL+"/oauth2/authorize?client_id=1000&") {
+		t.Fatalf("Expected auth link to Discord after loading the application ID, got %s, %v", link, err)
+	}
+}
@@ -0,0 +1,160 @@
+package unit_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+// mapStore is a CacheStore kept in a map, standing in for Redis.
+type mapStore struct {
+	entries map[string][]byte
+	ttls    map[string]time.Duration
+	err     error
+}
+
+func newMapStore() *mapStore {
+	return &mapStore{entries: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (s *mapStore) Get(key string) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	value, ok := s.entries[key]
+	if !ok {
+		return nil, discordapp.ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (s *mapStore) Set(key string, value []byte, ttl time.Duration) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.entries[key] = value
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *mapStore) Delete(key string) error {
+	delete(s.entries, key)
+	return nil
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := discordapp.NewLRUCache(2, 0)
+	cache.Set("a", discordapp.Guild{ID: 1})
+	cache.Set("b", &discordapp.Guild{ID: 2})
+	var guild discordapp.Guild
+	if !cache.Get("a", &guild) || guild.ID != 1 {
+		t.Fatalf("Expected cached guild, got %+v", guild)
+	}
+	cache.Set("c", discordapp.Guild{ID: 3})
+	if cache.Get("b", &guild) {
+		t.Fatalf("Expected least recently used entry to be evicted")
+	}
+	if !cache.Get("a", &guild) || !cache.Get("c", &guild) || guild.ID != 3 {
+		t.Fatalf("Expected recently used entries to be kept")
+	}
+	var member discordapp.Member
+	if cache.Get("a", &member) {
+		t.Fatalf("Expected entry of another type to miss")
+	}
+	stats := cache.Stats()
+	if stats.Hits != 3 || stats.Misses != 2 {
+		t.Fatalf("Expected 3 hits & 2 misses, got %+v", stats)
+	}
+
+	expiring := discordapp.NewLRUCache(0, time.Nanosecond)
+	expiring.Set("a", discordapp.Guild{ID: 1})
+	time.Sleep(time.Millisecond)
+	if expiring.Get("a", &guild) || expiring.Len() != 0 {
+		t.Fatalf("Expected expired entry to be removed")
+	}
+}
+
+func TestStoreCache(t *testing.T) {
+	store := newMapStore()
+	var failures int
+	cache := discordapp.NewStoreCache(store, "bot:", time.Minute)
+	cache.OnError = func(key string, err error) { failures++ }
+	cache.Set(discordapp.GuildCacheKey(2000), discordapp.Guild{ID: 2000, Name: "Test Guild"})
+	if store.ttls["bot:guild:2000"] != time.Minute {
+		t.Fatalf("Expected entry to be stored under the prefixed key with the cache's TTL, got %v", store.entries)
+	}
+	var guild discordapp.Guild
+	if !cache.Get(discordapp.GuildCacheKey(2000), &guild) || guild.Name != "Test Guild" {
+		t.Fatalf("Expected guild to be read back from its JSON, got %+v", guild)
+	}
+	cache.Delete(discordapp.GuildCacheKey(2000))
+	if cache.Get(discordapp.GuildCacheKey(2000), &guild) {
+		t.Fatalf("Expected deleted entry to miss")
+	}
+	store.err = errors.New("connection refused")
+	if cache.Get(discordapp.GuildCacheKey(2000), &guild) {
+		t.Fatalf("Expected failed lookup to miss")
+	}
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Errors != 1 || failures != 1 {
+		t.Fatalf("Expected 1 hit, 2 misses & 1 error, got %+v & %d failures", stats, failures)
+	}
+}
+
+func TestBotCache(t *testing.T) {
+	base, last, _ := interactionServer(t, http.StatusOK, `{"id": "2000", "name": "Fetched", "approximate_member_count": 5}`)
+	cache := discordapp.NewStoreCache(newMapStore(), "", 0)
+	bot := &discordapp.Bot{Token: "token", BaseURL: base, Cache: cache}
+	guild, err := bot.FetchGuild(2000)
+	if err != nil {
+		t.Fatalf("Error fetching guild: %s", err)
+	}
+	if guild.Name != "Fetched" || last.Method != http.MethodGet {
+		t.Fatalf("Expected guild to be fetched on a miss")
+	}
+	*last = http.Request{}
+	guild, err = bot.FetchGuild(2000)
+	if err != nil {
+		t.Fatalf("Error fetching guild: %s", err)
+	}
+	if guild.Name != "Fetched" || last.Method != "" {
+		t.Fatalf("Expected cached guild to be returned without a request")
+	}
+
+	_, err = bot.FetchApplicationInfo()
+	if err != nil {
+		t.Fatalf("Error fetching application info: %s", err)
+	}
+	*last = http.Request{}
+	_, err = bot.FetchApplicationInfo()
+	if err != nil {
+		t.Fatalf("Error fetching application info: %s", err)
+	}
+	if last.Method != "" {
+		t.Fatalf("Expected cached application info to be returned without a request")
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 2 {
+		t.Fatalf("Expected 2 hits & 2 misses, got %+v", stats)
+	}
+}
+
+func TestBotCacheInvalidation(t *testing.T) {
+	base, _, _ := interactionServer(t, http.StatusOK, `{"id": "2000", "name": "Fetched"}`)
+	cache := discordapp.NewLRUCache(0, 0)
+	bot := &discordapp.Bot{Token: "token", BaseURL: base, Cache: cache}
+	_, err := bot.FetchGuild(2000)
+	if err != nil {
+		t.Fatalf("Error fetching guild: %s", err)
+	}
+	_, err = bot.CreateGuildRole(2000, discordapp.CreateGuildRoleParams{})
+	if err != nil {
+		t.Fatalf("Error creating role: %s", err)
+	}
+	var guild discordapp.Guild
+	if cache.Get(discordapp.GuildCacheKey(2000), &guild) {
+		t.Fatalf("Expected creating a role to delete its cached guild")
+	}
+}
@@ -0,0 +1,66 @@
+package unit_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestGuildIconURL(t *testing.T) {
+	guild := discordapp.Guild{ID: 41771983423143937, Icon: "a_1269e74af4df7417b13759eae50c83dc"}
+	link, err := guild.IconURL("", 0)
+	if err != nil {
+		t.Fatalf("Error building icon url: %s", err)
+	}
+	if link != "https://cdn.discordapp.com/icons/41771983423143937/a_1269e74af4df7417b13759eae50c83dc.gif" {
+		t.Fatalf("Expected animated icon to default to gif: %s", link)
+	}
+	link, err = guild.IconURL(discordapp.ImageFormatWebP, 256)
+	if err != nil {
+		t.Fatalf("Error building icon url: %s", err)
+	}
+	if link != "https://cdn.discordapp.com/icons/41771983423143937/a_1269e74af4df7417b13759eae50c83dc.webp?size=256" {
+		t.Fatalf("Unexpected icon url: %s", link)
+	}
+	for _, size := range []int{8, 100, 8192} {
+		_, err = guild.IconURL("", size)
+		if !errors.Is(err, discordapp.ErrInvalidImageSize) {
+			t.Fatalf("Expected ErrInvalidImageSize for %d: %s", size, err)
+		}
+	}
+	guild.Icon = "1269e74af4df7417b13759eae50c83dc"
+	_, err = guild.IconURL(discordapp.ImageFormatGIF, 0)
+	if !errors.Is(err, discordapp.ErrInvalidImageFormat) {
+		t.Fatalf("Expected ErrInvalidImageFormat for static gif: %s", err)
+	}
+	guild.Banner = ""
+	link, err = guild.BannerURL("", 0)
+	if err != nil || link != "" {
+		t.Fatalf("Expected no banner url: %s %s", link, err)
+	}
+}
+
+func TestAvatarURL(t *testing.T) {
+	user := discordapp.MemberUser{ID: 80351110224678912, Discriminator: "1337"}
+	link, _ := user.AvatarURL("", 0)
+	if link != "https://cdn.discordapp.com/embed/avatars/2.png" {
+		t.Fatalf("Expected legacy default avatar: %s", link)
+	}
+	user.Discriminator = "0"
+	link, _ = user.AvatarURL("", 0)
+	if link != "https://cdn.discordapp.com/embed/avatars/5.png" {
+		t.Fatalf("Expected new username default avatar: %s", link)
+	}
+	user.Avatar = "8342729096ea3675442027381ff50dfe"
+	member := discordapp.Member{User: user}
+	link, _ = member.AvatarURL(41771983423143937, discordapp.ImageFormatJPEG, 64)
+	if link != "https://cdn.discordapp.com/avatars/80351110224678912/8342729096ea3675442027381ff50dfe.jpg?size=64" {
+		t.Fatalf("Expected member avatar to fall back to user avatar: %s", link)
+	}
+	member.Avatar = "a_d5efa99b3eeaa7dd43acca82f5692432"
+	link, _ = member.AvatarURL(41771983423143937, "", 0)
+	if link != "https://cdn.discordapp.com/guilds/41771983423143937/users/80351110224678912/avatars/a_d5efa99b3eeaa7dd43acca82f5692432.gif" {
+		t.Fatalf("Expected guild member avatar: %s", link)
+	}
+}
@@ -0,0 +1,70 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestCommandPermissionSentinels(t *testing.T) {
+	var guildID discordapp.Snowflake = 41771983423143937
+	everyone := discordapp.EveryoneCommandPermission(guildID, false)
+	if everyone.ID != guildID || everyone.Type != discordapp.ApplicationCommandPermissionTypeRole {
+		t.Fatalf("Unexpected @everyone permission: %+v", everyone)
+	}
+	channels := discordapp.AllChannelsCommandPermission(guildID, true)
+	if channels.ID != 41771983423143936 || channels.Type != discordapp.ApplicationCommandPermissionTypeChannel {
+		t.Fatalf("Unexpected all channels permission: %+v", channels)
+	}
+}
+
+func TestEditCommandPermissions(t *testing.T) {
+	var last http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/@me" {
+			scopes := []string{discordapp.ScopeIdentify}
+			if r.Header.Get("Authorization") == "Bearer access-token" {
+				scopes = append(scopes, discordapp.ScopeApplicationsCommandsPermissionsUpdate)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"scopes": scopes})
+			return
+		}
+		last = *r
+		body, _ = io.ReadAll(r.Body)
+		io.WriteString(w, `{"id":"3000","application_id":"1000","guild_id":"2000","permissions":[{"id":"2000","type":1,"permission":false}]}`)
+	}))
+	defer server.Close()
+	application := &discordapp.Application{Bot: &discordapp.Bot{BaseURL: server.URL, Application: &discordapp.ApplicationInfo{ID: 1000}}}
+	edited, err := application.EditCommandPermissions("access-token", 2000, 3000, []discordapp.ApplicationCommandPermission{discordapp.EveryoneCommandPermission(2000, false)})
+	if err != nil {
+		t.Fatalf("Error editing command permissions: %s", err)
+	}
+	if last.Method != http.MethodPut || last.URL.Path != "/applications/1000/guilds/2000/commands/3000/permissions" || last.Header.Get("Authorization") != "Bearer access-token" {
+		t.Fatalf("Unexpected request: %s %s %q", last.Method, last.URL.Path, last.Header.Get("Authorization"))
+	}
+	var sent struct {
+		Permissions []discordapp.ApplicationCommandPermission `json:"permissions"`
+	}
+	err = json.Unmarshal(body, &sent)
+	if err != nil || len(sent.Permissions) != 1 || sent.Permissions[0].ID != 2000 {
+		t.Fatalf("Unexpected body: %s", body)
+	}
+	if len(edited.Permissions) != 1 || edited.Permissions[0].Permission {
+		t.Fatalf("Unexpected permissions: %+v", edited)
+	}
+	_, err = application.EditCommandPermissions("access-token", 2000, 3000, make([]discordapp.ApplicationCommandPermission, 101))
+	if !errors.Is(err, discordapp.ErrInvalidCommandPermissions) {
+		t.Fatalf("Expected ErrInvalidCommandPermissions: %v", err)
+	}
+	last = http.Request{}
+	_, err = application.EditCommandPermissions("identify-only-token", 2000, 3000, nil)
+	if !errors.Is(err, discordapp.ErrMissingScope) || last.Method != "" {
+		t.Fatalf("Expected a token without the scope to be rejected before the permissions are sent: %v", err)
+	}
+}
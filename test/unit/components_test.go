@@ -0,0 +1,136 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestComponentJSON(t *testing.T) {
+	row := discordapp.NewActionRow(
+		discordapp.NewButton(discordapp.ButtonStylePrimary, "Yes", "yes"),
+		discordapp.NewLinkButton("Docs", "https://discord.com"),
+	)
+	data, err := json.Marshal([]discordapp.Component{row, discordapp.Separator{}})
+	if err != nil {
+		t.Fatalf("Error marshaling components: %s", err)
+	}
+	expected := `[{"type":1,"components":[{"type":2,"style":1,"label":"Yes","custom_id":"yes"},{"type":2,"style":5,"label":"Docs","url":"https://discord.com"}]},{"type":14}]`
+	if string(data) != expected {
+		t.Fatalf("Unexpected json: %s", data)
+	}
+	data, err = json.Marshal(discordapp.NewChannelSelect("channel", 0))
+	if err != nil || string(data) != `{"type":8,"custom_id":"channel","channel_types":[0]}` {
+		t.Fatalf("Unexpected select json: %s %v", data, err)
+	}
+}
+
+func TestValidateMessageComponents(t *testing.T) {
+	button := func(customID string) discordapp.Component {
+		return discordapp.NewButton(discordapp.ButtonStyleSecondary, "Button", customID)
+	}
+	valid := []discordapp.Component{
+		discordapp.NewActionRow(button("a"), button("b")),
+		discordapp.NewActionRow(discordapp.NewStringSelect("select", discordapp.SelectOption{Label: "One", Value: "1"})),
+		discordapp.NewActionRow(discordapp.NewPremiumButton(1234)),
+	}
+	err := discordapp.ValidateMessageComponents(valid, 0)
+	if err != nil {
+		t.Fatalf("Expected valid components: %s", err)
+	}
+	invalid := map[string][]discordapp.Component{
+		"too many buttons":    {discordapp.NewActionRow(button("a"), button("b"), button("c"), button("d"), button("e"), button("f"))},
+		"duplicate custom id": {discordapp.NewActionRow(button("a"), button("a"))},
+		"select with button":  {discordapp.NewActionRow(button("a"), discordapp.NewUserSelect("user"))},
+		"long custom id":      {discordapp.NewActionRow(button(strings.Repeat("a", 101)))},
+		"link with custom id": {discordapp.NewActionRow(discordapp.Button{Style: discordapp.ButtonStyleLink, Label: "x", URL: "https://discord.com", CustomID: "a"})},
+		"layout without flag": {discordapp.TextDisplay{Content: "hi"}},
+		"too many rows":       {discordapp.NewActionRow(button("1")), discordapp.NewActionRow(button("2")), discordapp.NewActionRow(button("3")), discordapp.NewActionRow(button("4")), discordapp.NewActionRow(button("5")), discordapp.NewActionRow(button("6"))},
+		"empty string select": {discordapp.NewActionRow(discordapp.NewStringSelect("select"))},
+		"nil component":       {nil},
+		"nil pointer":         {discordapp.NewActionRow((*discordapp.Button)(nil))},
+		"nil row":             {(*discordapp.ActionRow)(nil)},
+	}
+	for name, components := range invalid {
+		err := discordapp.ValidateMessageComponents(components, 0)
+		if !errors.Is(err, discordapp.ErrInvalidComponents) {
+			t.Fatalf("Expected ErrInvalidComponents for %s: %v", name, err)
+		}
+	}
+	layout := []discordapp.Component{
+		discordapp.NewContainer(
+			discordapp.NewSection(discordapp.Thumbnail{Media: discordapp.UnfurledMediaItem{URL: "attachment://a.png"}}, "Title", "Body"),
+			discordapp.Separator{Spacing: discordapp.SeparatorSpacingLarge},
+			discordapp.NewActionRow(button("a")),
+		),
+	}
+	err = discordapp.ValidateMessageComponents(layout, discordapp.MessageFlagIsComponentsV2)
+	if err != nil {
+		t.Fatalf("Expected valid layout: %s", err)
+	}
+	err = discordapp.ValidateMessageComponents([]discordapp.Component{discordapp.NewContainer(nil)}, discordapp.MessageFlagIsComponentsV2)
+	if !errors.Is(err, discordapp.ErrInvalidComponents) {
+		t.Fatalf("Expected nil layout component to be rejected: %v", err)
+	}
+	nested := []discordapp.Component{discordapp.NewContainer(discordapp.NewContainer())}
+	err = discordapp.ValidateMessageComponents(nested, discordapp.MessageFlagIsComponentsV2)
+	if !errors.Is(err, discordapp.ErrInvalidComponents) {
+		t.Fatalf("Expected nested containers to be rejected: %v", err)
+	}
+}
+
+func TestValidateModal(t *testing.T) {
+	modal := discordapp.NewModal("feedback", "Feedback", discordapp.NewTextInput("body", "Your feedback", discordapp.TextInputStyleParagraph))
+	err := discordapp.ValidateModal(modal)
+	if err != nil {
+		t.Fatalf("Expected valid modal: %s", err)
+	}
+	modal.Components = append(modal.Components, discordapp.NewActionRow(discordapp.NewButton(discordapp.ButtonStylePrimary, "No", "no")))
+	err = discordapp.ValidateModal(modal)
+	if !errors.Is(err, discordapp.ErrInvalidComponents) {
+		t.Fatalf("Expected button in modal to be rejected: %v", err)
+	}
+}
+
+func TestCustomID(t *testing.T) {
+	customID, err := discordapp.EncodeCustomID("secret", "ticket:close:", "42", "a:b%c")
+	if err != nil {
+		t.Fatalf("Error encoding custom id: %s", err)
+	}
+	if !strings.HasPrefix(customID, "ticket:close:") || len(customID) > discordapp.MaxCustomIDLength {
+		t.Fatalf("Unexpected custom id: %s", customID)
+	}
+	values, err := discordapp.DecodeCustomID("secret", "ticket:close:", customID)
+	if err != nil {
+		t.Fatalf("Error decoding custom id: %s", err)
+	}
+	if len(values) != 2 || values[0] != "42" || values[1] != "a:b%c" {
+		t.Fatalf("Unexpected values: %q", values)
+	}
+	tampered := strings.Replace(customID, "42", "43", 1)
+	_, err = discordapp.DecodeCustomID("secret", "ticket:close:", tampered)
+	if !errors.Is(err, discordapp.ErrInvalidCustomID) {
+		t.Fatalf("Expected tampered custom id to be rejected: %v", err)
+	}
+	_, err = discordapp.DecodeCustomID("other", "ticket:close:", customID)
+	if !errors.Is(err, discordapp.ErrInvalidCustomID) {
+		t.Fatalf("Expected wrong secret to be rejected: %v", err)
+	}
+	_, err = discordapp.EncodeCustomID("secret", "ticket:", strings.Repeat("a", 90))
+	if !errors.Is(err, discordapp.ErrInvalidCustomID) {
+		t.Fatalf("Expected long custom id to be rejected: %v", err)
+	}
+	router := discordapp.NewRouter()
+	var routed []string
+	router.Component("ticket:close:", func(ctx *discordapp.InteractionContext) error {
+		routed, err = ctx.CustomIDValues("secret")
+		return err
+	})
+	err = router.Handle(newInteraction(discordapp.InteractionTypeMessageComponent, `{"custom_id": "`+customID+`", "component_type": 2}`))
+	if err != nil || len(routed) != 2 {
+		t.Fatalf("Expected routed values: %v %q", err, routed)
+	}
+}
@@ -0,0 +1,76 @@
+package unit_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+	"github.com/kodishim/discordapp/discordapp/discordtest"
+)
+
+// useServer starts a new discordtest.Server for the duration of the test.
+func useServer(t *testing.T) *discordtest.Server {
+	server := discordtest.NewServer("test-token", "test-secret", discordapp.ApplicationInfo{ID: 1000, Name: "discordapp"})
+	t.Cleanup(server.Close)
+	server.AddGuild(discordapp.Guild{ID: 2000, Name: "guild"})
+	server.AddMember(2000, discordapp.Member{User: discordapp.MemberUser{ID: 3000, Username: "member"}})
+	return server
+}
+
+func TestDiscordtestSetError(t *testing.T) {
+	server := useServer(t)
+	bot, err := discordapp.NewBotWithConfig("test-token", discordapp.BotConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	_, err = bot.FetchGuildMember(2000, 3000)
+	if err != nil {
+		t.Fatalf("Error fetching member: %s", err)
+	}
+	server.SetError("GET", "/guilds/2000/members/3000", discordtest.ErrorUnknownMember)
+	_, err = bot.FetchGuildMember(2000, 3000)
+	if err != discordapp.ErrUserNotFound {
+		t.Fatalf("Expected ErrUserNotFound: %s", err)
+	}
+	server.SetError("GET", "/guilds/2000/members/3000", 0)
+	_, err = bot.FetchGuildMember(2000, 3000)
+	if err != nil {
+		t.Fatalf("Expected error to be removed: %s", err)
+	}
+	server.SetError("GET", "/guilds/2000/roles", discordtest.ErrorMissingPermissions)
+	_, err = bot.ListGuildRoles(2000)
+	if err != discordapp.ErrMissingPermissions {
+		t.Fatalf("Expected ErrMissingPermissions: %s", err)
+	}
+	_, err = discordapp.NewBotWithConfig("wrong-token", discordapp.BotConfig{BaseURL: server.URL})
+	if err == nil {
+		t.Fatalf("Expected invalid token to be rejected")
+	}
+}
+
+func TestDiscordtestRateLimit(t *testing.T) {
+	server := useServer(t)
+	server.SetRateLimit(1, time.Minute)
+	get := func() *http.Response {
+		req, err := http.NewRequest("GET", server.URL+"/guilds/2000", nil)
+		if err != nil {
+			t.Fatalf("Error creating request: %s", err)
+		}
+		req.Header.Set("Authorization", "Bot test-token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Error making request: %s", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	resp := get()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Fatalf("Expected first request to pass with no remaining requests: %d %q", resp.StatusCode, resp.Header.Get("X-RateLimit-Remaining"))
+	}
+	resp = get()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" || resp.Header.Get("X-RateLimit-Bucket") == "" {
+		t.Fatalf("Expected rate limited response: %d %v", resp.StatusCode, resp.Header)
+	}
+}
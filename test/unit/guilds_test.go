@@ -0,0 +1,31 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestModifyGuildParamsClear(t *testing.T) {
+	afkChannelID := discordapp.Snowflake(0)
+	systemChannelID := discordapp.Snowflake(4000)
+	description := ""
+	data, err := json.Marshal(discordapp.ModifyGuildParams{
+		AfkChannelID:    &afkChannelID,
+		SystemChannelID: &systemChannelID,
+		Icon:            []byte{},
+		Description:     &description,
+	})
+	if err != nil {
+		t.Fatalf("Error marshaling params: %s", err)
+	}
+	expected := `{"afk_channel_id":null,"icon":null,"system_channel_id":"4000","description":null}`
+	if string(data) != expected {
+		t.Fatalf("Expected %s, got %s", expected, data)
+	}
+	data, _ = json.Marshal(discordapp.ModifyGuildParams{})
+	if string(data) != "{}" {
+		t.Fatalf("Expected nil fields to be left out, got %s", data)
+	}
+}
@@ -0,0 +1,129 @@
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+type recordingTracer struct {
+	names []string
+	ended []discordapp.RequestInfo
+}
+
+func (r *recordingTracer) StartSpan(ctx context.Context, name string, info discordapp.RequestInfo) func(discordapp.RequestInfo) {
+	r.names = append(r.names, name)
+	info.Header.Set("Traceparent", name)
+	return func(info discordapp.RequestInfo) {
+		r.ended = append(r.ended, info)
+	}
+}
+
+func useHooks(t *testing.T, hooks ...discordapp.RequestHook) {
+	previous := discordapp.HTTPClient
+	discordapp.HTTPClient = &http.Client{Transport: discordapp.NewHookTransport(nil, hooks...)}
+	t.Cleanup(func() {
+		discordapp.HTTPClient = previous
+	})
+}
+
+func TestRouteTemplate(t *testing.T) {
+	routes := map[string]string{
+		"https://discord.com/api/guilds/2000/members/3000?limit=5":              "/guilds/{id}/members/{id}",
+		"https://discord.com/api/webhooks/1000/secret-token/messages/@original": "/webhooks/{id}/{token}/messages/@original",
+		"https://discord.com/api/interactions/1000/secret-token/callback":       "/interactions/{id}/{token}/callback",
+		"https://discord.com/api/webhooks/1000":                                 "/webhooks/{id}",
+		"https://discord.com/api/oauth2/applications/@me":                       "/oauth2/applications/@me",
+	}
+	for link, expected := range routes {
+		u, _ := url.Parse(link)
+		if route := discordapp.RouteTemplate(u); route != expected {
+			t.Fatalf("Expected route of %s to be %s, got %s", link, expected, route)
+		}
+	}
+}
+
+func TestRequestHooks(t *testing.T) {
+	var logs bytes.Buffer
+	tracer := &recordingTracer{}
+	useHooks(t, discordapp.SlogHook(slog.New(slog.NewTextHandler(&logs, nil))), discordapp.TracingHook(tracer))
+	base, _, _ := interactionServer(t, http.StatusOK, `{"id": "3000"}`)
+	bot := &discordapp.Bot{Token: "secret-bot-token", BaseURL: base}
+	_, err := bot.FetchGuildMember(2000, 3000)
+	if err != nil {
+		t.Fatalf("Error fetching member: %s", err)
+	}
+	if len(tracer.names) != 1 || tracer.names[0] != "GET /guilds/{id}/members/{id}" {
+		t.Fatalf("Expected a span for the request, got %v", tracer.names)
+	}
+	if len(tracer.ended) != 1 || tracer.ended[0].Status != http.StatusOK || tracer.ended[0].Latency <= 0 {
+		t.Fatalf("Expected span to end with the response status & latency, got %+v", tracer.ended)
+	}
+	if !strings.Contains(logs.String(), "route=/guilds/{id}/members/{id}") || strings.Contains(logs.String(), "secret-bot-token") {
+		t.Fatalf("Expected request to be logged without the token, got %s", logs.String())
+	}
+
+	// A base URL with its own path, e.g. a proxy, isn't part of the route.
+	bot.BaseURL = base + "/discord/api"
+	_, err = bot.FetchGuildMember(2000, 3000)
+	if err != nil {
+		t.Fatalf("Error fetching member: %s", err)
+	}
+	proxied := discordapp.NewWebhookClient(1000, "secret-webhook-token")
+	proxied.BaseURL = base + "/discord/api"
+	_, err = proxied.Execute(discordapp.ExecuteWebhookParams{Content: "hi"}, true, 0)
+	if err != nil {
+		t.Fatalf("Error executing webhook: %s", err)
+	}
+	if len(tracer.names) != 3 || tracer.names[1] != "GET /guilds/{id}/members/{id}" || tracer.names[2] != "POST /webhooks/{id}/{token}" {
+		t.Fatalf("Expected routes relative to the base URL, got %v", tracer.names)
+	}
+
+	webhook := discordapp.NewWebhookClient(1000, "secret-webhook-token")
+	webhook.BaseURL = "http://127.0.0.1:1"
+	_, _ = webhook.Execute(discordapp.ExecuteWebhookParams{Content: "hi"}, false, 0)
+	last := tracer.ended[len(tracer.ended)-1]
+	if last.Err == nil || strings.Contains(last.Err.Error(), "secret-webhook-token") {
+		t.Fatalf("Expected failed request's error without the webhook token, got %v", last.Err)
+	}
+}
+
+func TestRequestRetries(t *testing.T) {
+	var traceparents, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		traceparents, bodies = append(traceparents, r.Header.Get("Traceparent")), append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			io.WriteString(w, `{"message": "You are being rate limited.", "retry_after": 0, "global": false}`)
+			return
+		}
+		io.WriteString(w, `{"id": "2001", "name": "Mod"}`)
+	}))
+	defer server.Close()
+	tracer := &recordingTracer{}
+	client := &http.Client{Transport: discordapp.NewHookTransport(nil, discordapp.TracingHook(tracer))}
+	bot := &discordapp.Bot{Token: "token", BaseURL: server.URL, HTTPClient: client}
+	role, err := bot.CreateGuildRole(2000, discordapp.CreateGuildRoleParams{Name: "Mod"})
+	if err != nil {
+		t.Fatalf("Error creating role: %s", err)
+	}
+	if role.ID != 2001 || len(bodies) != 2 || bodies[1] == "" || bodies[0] != bodies[1] {
+		t.Fatalf("Expected rate limited request to be resent with its body, got %q", bodies)
+	}
+	if len(tracer.ended) != 2 || tracer.ended[0].Retries != 0 || tracer.ended[1].Retries != 1 || tracer.ended[1].Status != http.StatusOK {
+		t.Fatalf("Expected each attempt to be reported with its retry count, got %+v", tracer.ended)
+	}
+	if traceparents[0] != "POST /guilds/{id}/roles" || traceparents[1] != traceparents[0] {
+		t.Fatalf("Expected the tracer's headers to be sent, got %q", traceparents)
+	}
+}
@@ -0,0 +1,200 @@
+package unit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+// interactionServer starts a server that records the last request & replies with status & body, returning its URL.
+func interactionServer(t *testing.T, status int, body string) (baseURL string, last *http.Request, lastBody *[]byte) {
+	var request http.Request
+	var requestBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = *r
+		requestBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &request, &requestBody
+}
+
+// payloadJSON returns the payload_json field of the passed multipart request.
+func payloadJSON(t *testing.T, req *http.Request, body []byte) map[string]any {
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("Error parsing content type: %s", err)
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("Expected a payload_json field: %s", err)
+		}
+		if part.FormName() != "payload_json" {
+			continue
+		}
+		var payload map[string]any
+		err = json.NewDecoder(part).Decode(&payload)
+		if err != nil {
+			t.Fatalf("Error decoding payload_json: %s", err)
+		}
+		return payload
+	}
+}
+
+func TestInteractionRespondFiles(t *testing.T) {
+	base, last, body := interactionServer(t, http.StatusNoContent, "")
+	interaction := discordapp.Interaction{ID: discordapp.SnowflakeFromTime(time.Now()), ApplicationID: 1000, Type: discordapp.InteractionTypeApplicationCommand, Token: "token"}
+	client := interaction.Client()
+	client.BaseURL = base
+	err := client.RespondMessage(discordapp.InteractionMessageParams{
+		Content: "report",
+		Files:   []discordapp.File{{Name: "report.txt", Description: "The report", Reader: strings.NewReader("data")}},
+	})
+	if err != nil {
+		t.Fatalf("Error responding: %s", err)
+	}
+	payload := payloadJSON(t, last, *body)
+	if _, ok := payload["attachments"]; ok {
+		t.Fatalf("Expected attachments to be left out of the top level, got %v", payload)
+	}
+	data, _ := payload["data"].(map[string]any)
+	attachments, _ := data["attachments"].([]any)
+	if len(attachments) != 1 || attachments[0].(map[string]any)["description"] != "The report" || data["content"] != "report" {
+		t.Fatalf("Expected attachments to be sent in the response's data, got %v", payload)
+	}
+}
+
+func TestInteractionRespond(t *testing.T) {
+	base, last, body := interactionServer(t, http.StatusNoContent, "")
+	interaction := discordapp.Interaction{ID: discordapp.SnowflakeFromTime(time.Now()), ApplicationID: 1000, Type: discordapp.InteractionTypeApplicationCommand, Token: "token"}
+	client := interaction.Client()
+	client.BaseURL = base
+	err := client.RespondAutocomplete(nil)
+	if err == nil {
+		t.Fatalf("Expected autocomplete result to be rejected for an application command")
+	}
+	err = client.RespondMessage(discordapp.InteractionMessageParams{Content: "hi", Ephemeral: true})
+	if err != nil {
+		t.Fatalf("Error responding: %s", err)
+	}
+	if last.URL.Path != "/interactions/"+interaction.ID.String()+"/token/callback" || last.Header.Get("Authorization") != "" {
+		t.Fatalf("Unexpected request: %s %q", last.URL.Path, last.Header.Get("Authorization"))
+	}
+	var sent struct {
+		Type int `json:"type"`
+		Data struct {
+			Content string `json:"content"`
+			Flags   int    `json:"flags"`
+		} `json:"data"`
+	}
+	err = json.Unmarshal(*body, &sent)
+	if err != nil {
+		t.Fatalf("Error unmarshaling response: %s", err)
+	}
+	if sent.Type != discordapp.InteractionCallbackTypeChannelMessageWithSource || sent.Data.Content != "hi" || sent.Data.Flags != discordapp.MessageFlagEphemeral {
+		t.Fatalf("Unexpected response: %s", *body)
+	}
+	err = client.Defer(false)
+	if err != discordapp.ErrInteractionAlreadyResponded {
+		t.Fatalf("Expected ErrInteractionAlreadyResponded: %s", err)
+	}
+}
+
+func TestInteractionWindows(t *testing.T) {
+	base, _, _ := interactionServer(t, http.StatusOK, `{"id":"1","content":"hi"}`)
+	client := discordapp.NewInteractionClient(1000, discordapp.SnowflakeFromTime(time.Now().Add(time.Minute)), "token")
+	client.BaseURL = base
+	message, err := client.CreateFollowUp(discordapp.InteractionMessageParams{Content: "hi"})
+	if err != nil {
+		t.Fatalf("Expected a client built from a stored token to send follow-ups: %s", err)
+	}
+	if message.ID != 1 {
+		t.Fatalf("Unexpected message: %+v", message)
+	}
+	err = client.Defer(true)
+	if err != nil {
+		t.Fatalf("Expected the response window not to be enforced with the local clock: %s", err)
+	}
+
+	base, _, _ = interactionServer(t, http.StatusUnauthorized, `{"message":"Invalid Webhook Token","code":50027}`)
+	expired := discordapp.NewInteractionClient(1000, discordapp.SnowflakeFromTime(time.Now()), "token")
+	expired.BaseURL = base
+	_, err = expired.EditOriginalResponse(discordapp.EditInteractionMessageParams{})
+	if err != discordapp.ErrInteractionTokenExpired {
+		t.Fatalf("Expected ErrInteractionTokenExpired: %s", err)
+	}
+	base, last, _ := interactionServer(t, http.StatusOK, `{"id":"1"}`)
+	expired.BaseURL = base
+	_, err = expired.FetchOriginalResponse()
+	if err != discordapp.ErrInteractionTokenExpired || last.Method != "" {
+		t.Fatalf("Expected a token Discord rejected as expired to be rejected without a request: %s", err)
+	}
+}
+
+func TestInteractionUnknown(t *testing.T) {
+	base, _, _ := interactionServer(t, http.StatusNotFound, `{"message":"Unknown interaction","code":10062}`)
+	client := discordapp.NewInteractionClient(1000, discordapp.SnowflakeFromTime(time.Now()), "token")
+	client.BaseURL = base
+	err := client.DeferUpdate()
+	if err != discordapp.ErrUnknownInteraction {
+		t.Fatalf("Expected ErrUnknownInteraction: %s", err)
+	}
+	if client.Responded() {
+		t.Fatalf("Expected failed response to not count as responded")
+	}
+}
+
+func TestEditMessageAttachments(t *testing.T) {
+	base, last, body := interactionServer(t, http.StatusOK, `{"id": "6000"}`)
+	webhook := discordapp.NewWebhookClient(1000, "token")
+	webhook.BaseURL = base
+	files := []discordapp.File{{Name: "new.txt", Reader: strings.NewReader("data")}}
+	_, err := webhook.EditMessage(6000, 0, discordapp.EditWebhookMessageParams{Files: files})
+	if err != nil {
+		t.Fatalf("Error editing message: %s", err)
+	}
+	if payload := payloadJSON(t, last, *body); payload["attachments"] != nil {
+		t.Fatalf("Expected attachments to be left out so existing attachments are kept, got %v", payload)
+	}
+
+	files = []discordapp.File{{Name: "new.txt", Reader: strings.NewReader("data")}}
+	_, err = webhook.EditMessage(6000, 0, discordapp.EditWebhookMessageParams{Attachments: &[]discordapp.Attachment{{ID: 7000}}, Files: files})
+	if err != nil {
+		t.Fatalf("Error editing message: %s", err)
+	}
+	attachments, _ := payloadJSON(t, last, *body)["attachments"].([]any)
+	if len(attachments) != 2 || attachments[0].(map[string]any)["id"] != "7000" || attachments[1].(map[string]any)["filename"] != "new.txt" {
+		t.Fatalf("Expected kept attachment followed by the new file, got %v", attachments)
+	}
+}
+
+func TestEditOriginalResponseComponentsV2(t *testing.T) {
+	base, last, body := interactionServer(t, http.StatusOK, `{"id": "6000"}`)
+	client := discordapp.NewInteractionClient(1000, discordapp.SnowflakeFromTime(time.Now()), "token")
+	client.BaseURL = base
+	components := []discordapp.Component{discordapp.TextDisplay{Content: "Done"}}
+	_, err := client.EditOriginalResponse(discordapp.EditInteractionMessageParams{Components: &components})
+	if err != nil {
+		t.Fatalf("Error editing original response: %s", err)
+	}
+	var payload struct {
+		Flags int `json:"flags"`
+	}
+	json.Unmarshal(*body, &payload)
+	if payload.Flags != discordapp.MessageFlagIsComponentsV2 || last.Method != http.MethodPatch {
+		t.Fatalf("Expected MessageFlagIsComponentsV2 to be sent with layout components, got %s", *body)
+	}
+}
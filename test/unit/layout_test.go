@@ -0,0 +1,329 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func testLayoutGuild() (*discordapp.Guild, []discordapp.Channel) {
+	general := discordapp.Snowflake(10)
+	category := discordapp.Snowflake(20)
+	guild := &discordapp.Guild{
+		ID:              1,
+		Name:            "Test Guild",
+		SystemChannelID: &general,
+		PreferredLocale: "en-US",
+		Roles: []discordapp.Role{
+			{ID: 1, Name: "@everyone", Permissions: discordapp.PermissionSendMessages},
+			{ID: 2, Name: "Moderators", Position: 2, Color: 255, Hoist: true},
+			{ID: 3, Name: "Bot", Position: 3, Managed: true},
+			{ID: 4, Name: "Old", Position: 1},
+		},
+	}
+	channels := []discordapp.Channel{
+		{ID: 20, Name: "Text", Type: discordapp.ChannelTypeGuildCategory},
+		{ID: 10, Name: "general", Type: discordapp.ChannelTypeGuildText, ParentID: &category, PermissionOverwrites: []discordapp.PermissionOverwrite{
+			{ID: 1, Type: discordapp.OverwriteTypeRole, Deny: discordapp.PermissionSendMessages},
+			{ID: 2, Type: discordapp.OverwriteTypeRole, Allow: discordapp.PermissionSendMessages},
+		}},
+		{ID: 11, Name: "lobby", Type: discordapp.ChannelTypeGuildVoice, Bitrate: 64000},
+	}
+	return guild, channels
+}
+
+func TestBuildGuildLayout(t *testing.T) {
+	guild, channels := testLayoutGuild()
+	layout, err := discordapp.BuildGuildLayout(guild, channels)
+	if err != nil {
+		t.Fatalf("Error building layout: %s", err)
+	}
+	if len(layout.Roles) != 3 || layout.Roles[0].Name != "Moderators" || layout.Roles[2].Name != discordapp.EveryoneRoleName {
+		t.Fatalf("Expected unmanaged roles from highest to lowest: %+v", layout.Roles)
+	}
+	if len(layout.Channels) != 2 || layout.Channels[0].Name != "lobby" || layout.Channels[1].Name != "Text" {
+		t.Fatalf("Expected uncategorized channels before categories: %+v", layout.Channels)
+	}
+	children := layout.Channels[1].Channels
+	if len(children) != 1 || children[0].Name != "general" || len(children[0].Overwrites) != 2 || children[0].Overwrites[0].Role != discordapp.EveryoneRoleName {
+		t.Fatalf("Expected general channel with overwrites by role name: %+v", children)
+	}
+	if layout.Settings.SystemChannel == nil || *layout.Settings.SystemChannel != "general" {
+		t.Fatalf("Expected system channel to be referred to by name")
+	}
+	data, err := json.Marshal(layout)
+	if err != nil {
+		t.Fatalf("Error marshaling layout: %s", err)
+	}
+	parsed, err := discordapp.ParseGuildLayout(data)
+	if err != nil {
+		t.Fatalf("Error parsing layout: %s", err)
+	}
+	plan, err := discordapp.DiffGuildLayout(guild, channels, parsed)
+	if err != nil {
+		t.Fatalf("Error diffing layout: %s", err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("Expected empty plan for unchanged layout:\n%s", plan)
+	}
+}
+
+func TestDiffGuildLayout(t *testing.T) {
+	guild, channels := testLayoutGuild()
+	layout, _ := discordapp.BuildGuildLayout(guild, channels)
+	layout.Settings.Name = "Renamed Guild"
+	layout.Roles = []discordapp.LayoutRole{
+		{Name: "Moderators", Color: 255, Hoist: true, Permissions: discordapp.PermissionKickMembers},
+		{Name: "Members"},
+		{Name: discordapp.EveryoneRoleName, Permissions: discordapp.PermissionSendMessages},
+	}
+	general := layout.Channels[1].Channels[0]
+	general.Topic = "Hello"
+	general.Overwrites = append(general.Overwrites, discordapp.LayoutOverwrite{Role: "Members", Allow: discordapp.PermissionAttachFiles})
+	layout.Channels = []discordapp.LayoutChannel{
+		general,
+		{Name: "Voice", Type: discordapp.ChannelTypeGuildCategory, Channels: []discordapp.LayoutChannel{
+			{Name: "lobby", Type: discordapp.ChannelTypeGuildVoice},
+		}},
+	}
+	plan, err := discordapp.DiffGuildLayout(guild, channels, layout)
+	if err != nil {
+		t.Fatalf("Error diffing layout: %s", err)
+	}
+	expected := "~ role Moderators (permissions)\n" +
+		"+ role Members\n" +
+		"+ channel Voice\n" +
+		"~ channel general (parent, topic, overwrites)\n" +
+		"~ channel Voice/lobby (parent)\n" +
+		"~ settings (name)\n" +
+		"- channel Text\n" +
+		"- role Old"
+	if plan.String() != expected {
+		t.Fatalf("Unexpected plan:\n%s\nexpected:\n%s", plan, expected)
+	}
+	layout.Channels[0].Overwrites = append(layout.Channels[0].Overwrites, discordapp.LayoutOverwrite{Role: "Unknown"})
+	_, err = discordapp.DiffGuildLayout(guild, channels, layout)
+	if !errors.Is(err, discordapp.ErrInvalidGuildLayout) {
+		t.Fatalf("Expected ErrInvalidGuildLayout for unknown role: %s", err)
+	}
+}
+
+func TestParseGuildLayout(t *testing.T) {
+	invalid := []string{
+		`{"settings": {"name": ""}}`,
+		`{"settings": {"name": "a"}, "unknown": true}`,
+		`{"settings": {"name": "a"}, "roles": [{"name": "a"}, {"name": "a"}]}`,
+		`{"settings": {"name": "a"}, "channels": [{"name": "a", "type": 4, "channels": [{"name": "b", "type": 4}]}]}`,
+		`{"settings": {"name": "a"}, "channels": [{"name": "a", "type": 0, "channels": [{"name": "b", "type": 0}]}]}`,
+		`{"settings": {"name": "a", "system_channel": "missing"}}`,
+		`{"settings": {"name": "a"}, "channels": [{"name": "a", "type": 0, "overwrites": [{"allow": "0", "deny": "0"}]}]}`,
+	}
+	for _, document := range invalid {
+		_, err := discordapp.ParseGuildLayout([]byte(document))
+		if !errors.Is(err, discordapp.ErrInvalidGuildLayout) {
+			t.Fatalf("Expected ErrInvalidGuildLayout for %s: %v", document, err)
+		}
+	}
+}
+
+func TestDiffGuildLayoutRenames(t *testing.T) {
+	guild, channels := testLayoutGuild()
+	layout, _ := discordapp.BuildGuildLayout(guild, channels)
+	layout.Roles[0].Name, layout.Roles[0].RenamedFrom = "Mods", "Moderators"
+	layout.Roles[0], layout.Roles[1] = layout.Roles[1], layout.Roles[0]
+	layout.Channels[1].Name, layout.Channels[1].RenamedFrom = "Chat", "Text"
+	layout.Channels[1].Channels[0].Name, layout.Channels[1].Channels[0].RenamedFrom = "main", "general"
+	layout.Channels[1].Channels[0].Overwrites[1].Role = "Mods"
+	system := "main"
+	layout.Settings.SystemChannel = &system
+	plan, err := discordapp.DiffGuildLayout(guild, channels, layout)
+	if err != nil {
+		t.Fatalf("Error diffing layout: %s", err)
+	}
+	expected := "~ role Mods (name)\n" +
+		"~ channel Chat (name)\n" +
+		"~ channel Chat/main (name)\n" +
+		"~ positions (roles)\n" +
+		"~ settings (system_channel)"
+	if plan.String() != expected {
+		t.Fatalf("Unexpected plan:\n%s\nexpected:\n%s", plan, expected)
+	}
+	order := plan.Changes[3].Order
+	if len(order.Roles) != 2 || order.Roles[0] != "Old" || order.Roles[1] != "Mods" {
+		t.Fatalf("Expected roles from highest to lowest without @everyone, got %v", order.Roles)
+	}
+
+	lobby := "lobby"
+	layout, _ = discordapp.BuildGuildLayout(guild, channels)
+	layout.Settings.AfkChannel = &lobby
+	err = layout.Validate()
+	if err != nil {
+		t.Fatalf("Expected AFK channel to refer to a voice channel: %s", err)
+	}
+	general := "general"
+	layout.Settings.AfkChannel = &general
+	if err = layout.Validate(); !errors.Is(err, discordapp.ErrInvalidGuildLayout) {
+		t.Fatalf("Expected ErrInvalidGuildLayout for a text AFK channel: %v", err)
+	}
+}
+
+func TestApplyGuildLayoutPlan(t *testing.T) {
+	guild, channels := testLayoutGuild()
+	type sent struct {
+		route string
+		body  string
+	}
+	var requests []sent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, sent{r.Method + " " + r.URL.Path, string(body)})
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /guilds/1/roles":
+			json.NewEncoder(w).Encode(guild.Roles)
+		case "GET /guilds/1/channels":
+			json.NewEncoder(w).Encode(channels)
+		case "PATCH /guilds/1/channels":
+			w.WriteHeader(http.StatusNoContent)
+		case "PATCH /guilds/1/roles":
+			io.WriteString(w, "[]")
+		default:
+			io.WriteString(w, `{"id": "99"}`)
+		}
+	}))
+	defer server.Close()
+
+	layout, _ := discordapp.BuildGuildLayout(guild, channels)
+	layout.Roles[0].Name, layout.Roles[0].RenamedFrom = "Mods", "Moderators"
+	layout.Roles[0], layout.Roles[1] = layout.Roles[1], layout.Roles[0]
+	general := layout.Channels[1].Channels[0]
+	general.Name, general.RenamedFrom = "main", "general"
+	general.Overwrites = []discordapp.LayoutOverwrite{general.Overwrites[0], {Role: "Mods", Allow: general.Overwrites[1].Allow}}
+	rules := discordapp.LayoutChannel{Name: "rules", Type: discordapp.ChannelTypeGuildText}
+	layout.Channels = []discordapp.LayoutChannel{rules, general, layout.Channels[0], layout.Channels[1]}
+	layout.Channels[3].Channels = nil
+	lobby := "lobby"
+	layout.Settings.AfkChannel, layout.Settings.SystemChannel = &lobby, nil
+	plan, err := discordapp.DiffGuildLayout(guild, channels, layout)
+	if err != nil {
+		t.Fatalf("Error diffing layout: %s", err)
+	}
+	bot := &discordapp.Bot{Token: "token", BaseURL: server.URL}
+	err = bot.ApplyGuildLayoutPlan(1, plan)
+	if err != nil {
+		t.Fatalf("Error applying plan:\n%s\n%s", plan, err)
+	}
+	expected := []sent{
+		{"GET /guilds/1/roles", ""},
+		{"GET /guilds/1/channels", ""},
+		{"PATCH /guilds/1/roles/2", `{"name":"Mods","permissions":"0","color":255,"hoist":true,"mentionable":false}`},
+		{"POST /guilds/1/channels", `{"name":"rules","type":0}`},
+		{"PATCH /channels/10", `{"name":"main","parent_id":null}`},
+		{"PATCH /guilds/1/roles", `[{"id":"4","position":2},{"id":"2","position":1}]`},
+		{"PATCH /guilds/1/channels", `[{"id":"99","position":0},{"id":"10","position":1},{"id":"11","position":2},{"id":"20","position":3}]`},
+		{"PATCH /guilds/1", `{"afk_channel_id":"11","system_channel_id":null}`},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected %d requests, got %v", len(expected), requests)
+	}
+	for i, request := range requests {
+		if request.route != expected[i].route || strings.TrimSpace(request.body) != expected[i].body {
+			t.Fatalf("Expected request %d to be %v, got %v", i, expected[i], request)
+		}
+	}
+}
+
+func TestGuildLayoutSharedNames(t *testing.T) {
+	first, second := discordapp.Snowflake(20), discordapp.Snowflake(21)
+	system := discordapp.Snowflake(12)
+	guild := &discordapp.Guild{ID: 1, Name: "Test Guild", SystemChannelID: &system, Roles: []discordapp.Role{{ID: 1, Name: "@everyone"}}}
+	channels := []discordapp.Channel{
+		{ID: 20, Name: "First", Type: discordapp.ChannelTypeGuildCategory, Position: 0},
+		{ID: 21, Name: "Second", Type: discordapp.ChannelTypeGuildCategory, Position: 1},
+		{ID: 10, Name: "general", Type: discordapp.ChannelTypeGuildText, ParentID: &first},
+		{ID: 12, Name: "general", Type: discordapp.ChannelTypeGuildText, ParentID: &second},
+	}
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /guilds/1":
+			json.NewEncoder(w).Encode(guild)
+		case "GET /guilds/1/roles":
+			json.NewEncoder(w).Encode(guild.Roles)
+		case "GET /guilds/1/channels":
+			json.NewEncoder(w).Encode(channels)
+		case "PATCH /guilds/1/channels":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			io.WriteString(w, `{"id": "99"}`)
+		}
+	}))
+	defer server.Close()
+	// A stale copy of the guild in the bot's cache, which plans must not be made against.
+	cache := discordapp.NewLRUCache(10, 0)
+	cache.Set(discordapp.GuildCacheKey(1), discordapp.Guild{ID: 1, Name: "Stale Guild"})
+	bot := &discordapp.Bot{Token: "token", BaseURL: server.URL, Cache: cache}
+
+	layout, err := bot.ExportGuildLayout(1)
+	if err != nil {
+		t.Fatalf("Error exporting layout: %s", err)
+	}
+	if layout.Settings.Name != "Test Guild" || layout.Settings.SystemChannel == nil || *layout.Settings.SystemChannel != "Second/general" {
+		t.Fatalf("Expected fresh settings referring to the system channel by category, got %+v", layout.Settings)
+	}
+	data, err := json.Marshal(layout)
+	if err != nil {
+		t.Fatalf("Error marshaling layout: %s", err)
+	}
+	parsed, err := discordapp.ParseGuildLayout(data)
+	if err != nil {
+		t.Fatalf("Error parsing exported layout: %s", err)
+	}
+	plan, err := bot.PlanGuildLayout(1, parsed)
+	if err != nil {
+		t.Fatalf("Error planning layout: %s", err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("Expected empty plan for unchanged layout:\n%s", plan)
+	}
+
+	parsed.Channels[1].Channels[0].Topic = "second"
+	parsed.Channels[0], parsed.Channels[1] = parsed.Channels[1], parsed.Channels[0]
+	plan, err = bot.PlanGuildLayout(1, parsed)
+	if err != nil {
+		t.Fatalf("Error planning layout: %s", err)
+	}
+	if plan.String() != "~ channel Second/general (topic)\n~ positions (channels)" || plan.Changes[0].ID != 12 {
+		t.Fatalf("Expected only the second general channel to change, got:\n%s", plan)
+	}
+	requests = nil
+	err = bot.ApplyGuildLayoutPlan(1, plan)
+	if err != nil {
+		t.Fatalf("Error applying plan: %s", err)
+	}
+	expected := []string{
+		"GET /guilds/1/roles",
+		"GET /guilds/1/channels",
+		`PATCH /channels/12 {"topic":"second"}`,
+		`PATCH /guilds/1/channels [{"id":"21","position":0},{"id":"12","position":1},{"id":"20","position":2},{"id":"10","position":3}]`,
+	}
+	if !slices.Equal(requests, expected) {
+		t.Fatalf("Expected requests %v, got %v", expected, requests)
+	}
+
+	guild.Roles = append(guild.Roles, discordapp.Role{ID: 2, Name: "Mods"}, discordapp.Role{ID: 3, Name: "Mods"})
+	_, err = discordapp.BuildGuildLayout(guild, channels)
+	if !errors.Is(err, discordapp.ErrInvalidGuildLayout) {
+		t.Fatalf("Expected ErrInvalidGuildLayout for roles sharing a name, got %v", err)
+	}
+}
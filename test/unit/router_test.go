@@ -0,0 +1,154 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+type banOptions struct {
+	Reason string                `discord:"reason" description:"Why the user is banned" max:"200"`
+	User   discordapp.MemberUser `discord:"user,required" description:"User to ban"`
+	Days   *int                  `discord:"days" min:"0" max:"7"`
+	Member *discordapp.Member    `discord:"member"`
+	Target discordapp.Snowflake  `discord:"target"`
+	Ignore string
+}
+
+func newInteraction(interactionType int, data string) *discordapp.Interaction {
+	return &discordapp.Interaction{
+		ID:            discordapp.SnowflakeFromTime(time.Now()),
+		ApplicationID: 1000,
+		Type:          interactionType,
+		Token:         "token",
+		Data:          json.RawMessage(data),
+		Member:        &discordapp.Member{User: discordapp.MemberUser{ID: 3000}},
+	}
+}
+
+func TestRouterCommands(t *testing.T) {
+	router := discordapp.NewRouter()
+	router.Command("ping", "Ping", nil, nil)
+	admin := router.Group("admin", "Admin commands")
+	admin.Command("ban", "Ban a user", banOptions{}, nil)
+	admin.Group("roles", "Manage roles").Command("add", "Add a role", nil, nil)
+	router.UserCommand("Info", nil)
+	commands := router.Commands()
+	if len(commands) != 3 || commands[1].Name != "admin" || len(commands[1].Options) != 2 {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+	ban := commands[1].Options[0]
+	if ban.Type != discordapp.ApplicationCommandOptionTypeSubCommand || len(ban.Options) != 5 {
+		t.Fatalf("Unexpected ban subcommand: %+v", ban)
+	}
+	if ban.Options[0].Name != "user" || !ban.Options[0].Required || ban.Options[0].Type != discordapp.ApplicationCommandOptionTypeUser {
+		t.Fatalf("Expected required user option first: %+v", ban.Options[0])
+	}
+	if *ban.Options[1].MaxLength != 200 || *ban.Options[2].MaxValue != 7 || ban.Options[4].Type != discordapp.ApplicationCommandOptionTypeMentionable {
+		t.Fatalf("Unexpected options: %+v", ban.Options)
+	}
+	roles := commands[1].Options[1]
+	if roles.Type != discordapp.ApplicationCommandOptionTypeSubCommandGroup || roles.Options[0].Name != "add" {
+		t.Fatalf("Unexpected roles group: %+v", roles)
+	}
+	_, err := discordapp.CommandOptions(struct {
+		Bad []string `discord:"bad"`
+	}{})
+	if !errors.Is(err, discordapp.ErrInvalidCommandOption) {
+		t.Fatalf("Expected ErrInvalidCommandOption: %s", err)
+	}
+}
+
+func TestRouterHandle(t *testing.T) {
+	router := discordapp.NewRouter()
+	var bound banOptions
+	var route string
+	router.Group("admin", "Admin commands").Command("ban", "Ban a user", banOptions{}, func(ctx *discordapp.InteractionContext) error {
+		route = ctx.Route
+		return ctx.Bind(&bound)
+	})
+	var suffix string
+	router.Component("ticket:", func(ctx *discordapp.InteractionContext) error {
+		suffix = "short " + ctx.CustomIDSuffix
+		return nil
+	})
+	router.Component("ticket:close:", func(ctx *discordapp.InteractionContext) error {
+		suffix = ctx.CustomIDSuffix
+		return nil
+	})
+	err := router.Handle(newInteraction(discordapp.InteractionTypeApplicationCommand, `{
+		"name": "admin",
+		"options": [{"name": "ban", "type": 1, "options": [
+			{"name": "user", "type": 6, "value": "4000"},
+			{"name": "days", "type": 4, "value": 3},
+			{"name": "member", "type": 6, "value": "4000"},
+			{"name": "target", "type": 9, "value": "5000"}
+		]}],
+		"resolved": {"users": {"4000": {"id": "4000", "username": "banned"}}, "members": {"4000": {"nick": "nick"}}}
+	}`))
+	if err != nil {
+		t.Fatalf("Error handling command: %s", err)
+	}
+	if route != "admin ban" || bound.User.Username != "banned" || *bound.Days != 3 || bound.Member.Nick != "nick" || bound.Member.User.ID != 4000 || bound.Target != 5000 {
+		t.Fatalf("Unexpected binding for %q: %+v", route, bound)
+	}
+	err = router.Handle(newInteraction(discordapp.InteractionTypeMessageComponent, `{"custom_id": "ticket:close:42", "component_type": 2}`))
+	if err != nil || suffix != "42" {
+		t.Fatalf("Expected longest prefix to match: %s %q", err, suffix)
+	}
+	err = router.Handle(newInteraction(discordapp.InteractionTypeModalSubmit, `{"custom_id": "unknown"}`))
+	if !errors.Is(err, discordapp.ErrNoInteractionHandler) {
+		t.Fatalf("Expected ErrNoInteractionHandler: %s", err)
+	}
+	err = router.Handle(newInteraction(discordapp.InteractionTypeApplicationCommand, `{"name": "admin", "options": [{"name": "ban", "type": 1, "options": []}]}`))
+	if !errors.Is(err, discordapp.ErrInvalidCommandOption) {
+		t.Fatalf("Expected missing required option to fail: %s", err)
+	}
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	base, last, _ := interactionServer(t, http.StatusNoContent, "")
+	router := discordapp.NewRouter()
+	router.BaseURL = base
+	router.Use(discordapp.Recover(), discordapp.Cooldown(time.Minute, "Slow down"), discordapp.RequirePermissions(discordapp.PermissionBanMembers, "No"))
+	calls := 0
+	router.Command("ban", "Ban", nil, func(ctx *discordapp.InteractionContext) error {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		return nil
+	})
+	allowed := func(userID discordapp.Snowflake) *discordapp.Interaction {
+		interaction := newInteraction(discordapp.InteractionTypeApplicationCommand, `{"name": "ban"}`)
+		interaction.Member.User.ID = userID
+		interaction.Member.Permissions = discordapp.PermissionBanMembers
+		return interaction
+	}
+	err := router.Handle(allowed(3000))
+	if err == nil || calls != 1 {
+		t.Fatalf("Expected panic to be recovered as an error: %v", err)
+	}
+	err = router.Handle(allowed(3000))
+	if err != nil || calls != 2 {
+		t.Fatalf("Expected a failed invocation not to start a cooldown: %v %d", err, calls)
+	}
+	err = router.Handle(allowed(3000))
+	if err != nil || calls != 2 || last.URL.Path == "" {
+		t.Fatalf("Expected cooldown response: %v %d", err, calls)
+	}
+	other := newInteraction(discordapp.InteractionTypeApplicationCommand, `{"name": "ban"}`)
+	other.Member.User.ID = 3001
+	err = router.Handle(other)
+	if err != nil || calls != 2 {
+		t.Fatalf("Expected missing permissions response: %v %d", err, calls)
+	}
+	err = router.Handle(allowed(3001))
+	if err != nil || calls != 3 {
+		t.Fatalf("Expected a rejected invocation not to start a cooldown: %v %d", err, calls)
+	}
+}
@@ -0,0 +1,91 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestValidateScheduledEventStatusTransition(t *testing.T) {
+	allowed := [][2]int{
+		{discordapp.ScheduledEventStatusScheduled, discordapp.ScheduledEventStatusActive},
+		{discordapp.ScheduledEventStatusScheduled, discordapp.ScheduledEventStatusCanceled},
+		{discordapp.ScheduledEventStatusActive, discordapp.ScheduledEventStatusCompleted},
+	}
+	for _, transition := range allowed {
+		err := discordapp.ValidateScheduledEventStatusTransition(transition[0], transition[1])
+		if err != nil {
+			t.Fatalf("Expected transition %d to %d to be allowed: %s", transition[0], transition[1], err)
+		}
+	}
+	disallowed := [][2]int{
+		{discordapp.ScheduledEventStatusScheduled, discordapp.ScheduledEventStatusCompleted},
+		{discordapp.ScheduledEventStatusActive, discordapp.ScheduledEventStatusScheduled},
+		{discordapp.ScheduledEventStatusActive, discordapp.ScheduledEventStatusCanceled},
+		{discordapp.ScheduledEventStatusCompleted, discordapp.ScheduledEventStatusActive},
+		{discordapp.ScheduledEventStatusCanceled, discordapp.ScheduledEventStatusScheduled},
+	}
+	for _, transition := range disallowed {
+		err := discordapp.ValidateScheduledEventStatusTransition(transition[0], transition[1])
+		if !errors.Is(err, discordapp.ErrInvalidStatusTransition) {
+			t.Fatalf("Expected ErrInvalidStatusTransition for %d to %d: %s", transition[0], transition[1], err)
+		}
+	}
+}
+
+func TestCreateScheduledEventValidation(t *testing.T) {
+	bot := &discordapp.Bot{}
+	_, err := bot.CreateScheduledEvent(111, discordapp.CreateScheduledEventParams{
+		Name:       "external without location",
+		EntityType: discordapp.ScheduledEventEntityTypeExternal,
+	})
+	if !errors.Is(err, discordapp.ErrInvalidScheduledEvent) {
+		t.Fatalf("Expected ErrInvalidScheduledEvent: %s", err)
+	}
+	_, err = bot.CreateScheduledEvent(111, discordapp.CreateScheduledEventParams{
+		Name:       "voice without channel",
+		EntityType: discordapp.ScheduledEventEntityTypeVoice,
+	})
+	if !errors.Is(err, discordapp.ErrInvalidScheduledEvent) {
+		t.Fatalf("Expected ErrInvalidScheduledEvent: %s", err)
+	}
+}
+
+func TestModifyScheduledEventExternal(t *testing.T) {
+	base, _, body := interactionServer(t, http.StatusOK, `{"id": "5000"}`)
+	bot := &discordapp.Bot{Token: "token", BaseURL: base}
+	entityType := discordapp.ScheduledEventEntityTypeExternal
+	_, err := bot.ModifyScheduledEvent(2000, 5000, discordapp.ModifyScheduledEventParams{
+		EntityType:     &entityType,
+		EntityMetadata: &discordapp.ScheduledEventEntityMetadata{Location: "The park"},
+	})
+	if err != nil {
+		t.Fatalf("Error modifying scheduled event: %s", err)
+	}
+	var payload map[string]any
+	json.Unmarshal(*body, &payload)
+	if channelID, ok := payload["channel_id"]; !ok || channelID != nil {
+		t.Fatalf("Expected channel_id to be sent as null when switching to an external event, got %s", *body)
+	}
+
+	channelID := discordapp.Snowflake(0)
+	_, err = bot.ModifyScheduledEvent(2000, 5000, discordapp.ModifyScheduledEventParams{ChannelID: &channelID})
+	if err != nil {
+		t.Fatalf("Error modifying scheduled event: %s", err)
+	}
+	payload = nil
+	json.Unmarshal(*body, &payload)
+	if channelID, ok := payload["channel_id"]; !ok || channelID != nil {
+		t.Fatalf("Expected a pointer to 0 to be sent as null, got %s", *body)
+	}
+	_, err = bot.ModifyScheduledEvent(2000, 5000, discordapp.ModifyScheduledEventParams{})
+	if err != nil {
+		t.Fatalf("Error modifying scheduled event: %s", err)
+	}
+	if string(*body) != "{}" {
+		t.Fatalf("Expected unset fields to be left out, got %s", *body)
+	}
+}
@@ -0,0 +1,84 @@
+package unit_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestSecretFormatting(t *testing.T) {
+	bot := &discordapp.Bot{Token: "secret-bot-token", Application: &discordapp.ApplicationInfo{ID: 1000, Name: "Test"}}
+	app := discordapp.Application{Bot: bot, Secret: "secret-client-secret"}
+	var logs bytes.Buffer
+	slog.New(slog.NewTextHandler(&logs, nil)).Info("started", "bot", bot, "app", app, "token", bot.Token)
+	for _, formatted := range []string{
+		fmt.Sprintf("%v %+v %#v %s", bot, bot, bot, bot.Token),
+		fmt.Sprintf("%v %+v %#v", app, &app, app),
+		fmt.Sprintf("%+v", struct{ Bot *discordapp.Bot }{bot}),
+		logs.String(),
+	} {
+		if strings.Contains(formatted, "secret-") || !strings.Contains(formatted, discordapp.Redacted) {
+			t.Fatalf("Expected credentials to be redacted, got %s", formatted)
+		}
+	}
+	if bot.Token.Value() != "secret-bot-token" {
+		t.Fatalf("Expected Value to return the token")
+	}
+	data, err := json.Marshal(struct {
+		Token    discordapp.Secret
+		Response discordapp.AccessTokenResponse
+	}{bot.Token, discordapp.AccessTokenResponse{AccessToken: "secret-access-token", RefreshToken: "secret-refresh-token"}})
+	if err != nil {
+		t.Fatalf("Error marshaling secrets: %s", err)
+	}
+	var unmarshaled struct {
+		Token    discordapp.Secret
+		Response discordapp.AccessTokenResponse
+	}
+	err = json.Unmarshal(data, &unmarshaled)
+	if err != nil || unmarshaled.Token != bot.Token || unmarshaled.Response.AccessToken != "secret-access-token" || unmarshaled.Response.RefreshToken != "secret-refresh-token" {
+		t.Fatalf("Expected credentials to survive a JSON round trip, got %s", data)
+	}
+}
+
+func TestSecretRequests(t *testing.T) {
+	base, last, _ := interactionServer(t, http.StatusBadRequest, `{"access_token": "leaked-access-token", "detail": "`+strings.Repeat("a", 1000)+`"}`)
+	app := discordapp.Application{Bot: &discordapp.Bot{Token: "secret-bot-token", BaseURL: base, Application: &discordapp.ApplicationInfo{ID: 1000}}, Secret: "secret-client-secret"}
+	_, err := app.FetchAccessTokenResponse("code", "https://example.com")
+	var unexpected *discordapp.UnexpectedResponseError
+	if !errors.As(err, &unexpected) {
+		t.Fatalf("Expected UnexpectedResponseError, got %v", err)
+	}
+	if strings.Contains(err.Error(), "leaked-access-token") || !strings.Contains(err.Error(), "bytes truncated") {
+		t.Fatalf("Expected error body to be scrubbed & truncated, got %s", err)
+	}
+	app.Bot.BaseURL, last, _ = interactionServer(t, http.StatusBadRequest, `{"detail": "`+strings.Repeat("é", 300)+`"}`)
+	_, err = app.FetchAccessTokenResponse("code", "https://example.com")
+	if err == nil || !utf8.ValidString(err.Error()) {
+		t.Fatalf("Expected error body to be truncated on a rune boundary, got %q", err)
+	}
+	credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(last.Header.Get("Authorization"), "Basic "))
+	if string(credentials) != "1000:secret-client-secret" {
+		t.Fatalf("Expected request to use the secret's value, got %s", credentials)
+	}
+	_, err = app.Bot.FetchGuild(2000)
+	if err == nil || last.Header.Get("Authorization") != "Bot secret-bot-token" {
+		t.Fatalf("Expected request to use the token's value, got %s", last.Header.Get("Authorization"))
+	}
+
+	webhook := discordapp.NewWebhookClient(1000, "secret-webhook-token")
+	webhook.BaseURL = "http://127.0.0.1:1"
+	_, err = webhook.Execute(discordapp.ExecuteWebhookParams{Content: "hi"}, false, 0)
+	if err == nil || strings.Contains(err.Error(), "secret-webhook-token") {
+		t.Fatalf("Expected failed request's error without the webhook token, got %v", err)
+	}
+}
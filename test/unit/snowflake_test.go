@@ -0,0 +1,59 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestSnowflake(t *testing.T) {
+	var decoded struct {
+		String discordapp.Snowflake  `json:"string"`
+		Number discordapp.Snowflake  `json:"number"`
+		Null   *discordapp.Snowflake `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"string": "175928847299117063", "number": 175928847299117063, "null": null}`), &decoded)
+	if err != nil {
+		t.Fatalf("Error unmarshaling snowflakes: %s", err)
+	}
+	id := decoded.String
+	if decoded.Number != id || decoded.Null != nil {
+		t.Fatalf("Expected snowflake to be parsed from strings & numbers")
+	}
+	if !id.Time().Equal(time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC)) {
+		t.Fatalf("Unexpected snowflake time: %s", id.Time())
+	}
+	if id.WorkerID() != 1 || id.ProcessID() != 0 || id.Increment() != 7 {
+		t.Fatalf("Unexpected snowflake parts: %d %d %d", id.WorkerID(), id.ProcessID(), id.Increment())
+	}
+	data, err := json.Marshal(id)
+	if err != nil {
+		t.Fatalf("Error marshaling snowflake: %s", err)
+	}
+	if string(data) != `"175928847299117063"` {
+		t.Fatalf("Expected snowflake to be marshaled as a string: %s", data)
+	}
+	_, err = discordapp.ParseSnowflake("not a snowflake")
+	if err == nil {
+		t.Fatalf("Expected error parsing invalid snowflake")
+	}
+}
+
+func TestSnowflakeFromTime(t *testing.T) {
+	id, _ := discordapp.ParseSnowflake("175928847299117063")
+	cursor := discordapp.SnowflakeFromTime(id.Time())
+	if !cursor.Time().Equal(id.Time()) || cursor.Compare(id) != -1 || id.Compare(cursor) != 1 {
+		t.Fatalf("Expected cursor at the same time & before the snowflake")
+	}
+	if discordapp.SnowflakeFromTime(id.Time().Add(time.Millisecond)).Compare(id) != 1 {
+		t.Fatalf("Expected later cursor to be after the snowflake")
+	}
+	ids := []discordapp.Snowflake{id, cursor, id + 1}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if ids[0] != cursor || ids[2] != id+1 {
+		t.Fatalf("Expected snowflakes to be sorted by creation time")
+	}
+}
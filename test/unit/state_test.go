@@ -0,0 +1,157 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+const guildCreateEvent = `{
+	"id": "2000",
+	"name": "Test Guild",
+	"roles": [{"id": "2000", "name": "@everyone"}, {"id": "2001", "name": "Mod"}],
+	"emojis": [{"id": "2100", "name": "wave"}],
+	"channels": [{"id": "5000", "name": "general", "type": 0}],
+	"threads": [{"id": "5001", "name": "thread", "type": 11}],
+	"members": [{"nick": "tester", "roles": ["2001"], "user": {"id": "3000", "username": "test"}}]
+}`
+
+func TestStateHandleEvent(t *testing.T) {
+	state := discordapp.NewState(discordapp.StateConfig{})
+	err := state.HandleEvent("GUILD_CREATE", json.RawMessage(guildCreateEvent))
+	if err != nil {
+		t.Fatalf("Error handling GUILD_CREATE: %s", err)
+	}
+	guild, ok := state.Guild(2000)
+	if !ok || guild.Name != "Test Guild" {
+		t.Fatalf("Expected guild to be cached, got %+v", guild)
+	}
+	if len(guild.Roles) != 2 || len(guild.Emojis) != 1 || len(guild.Channels) != 2 {
+		t.Fatalf("Expected guild to be rebuilt with its roles, emojis, channels & threads, got %+v", guild)
+	}
+	thread, ok := state.Channel(5001)
+	if !ok || thread.GuildID != 2000 {
+		t.Fatalf("Expected thread to be cached with its guild ID, got %+v", thread)
+	}
+	if _, ok := state.User(3000); !ok {
+		t.Fatalf("Expected member's user to be cached")
+	}
+
+	err = state.HandleEvent("GUILD_MEMBER_UPDATE", json.RawMessage(`{"guild_id": "2000", "user": {"id": "3000", "username": "test"}, "roles": []}`))
+	if err != nil {
+		t.Fatalf("Error handling GUILD_MEMBER_UPDATE: %s", err)
+	}
+	member, ok := state.Member(2000, 3000)
+	if !ok || member.Nick != "tester" || len(member.Roles) != 0 {
+		t.Fatalf("Expected update to be merged into the cached member, got %+v", member)
+	}
+
+	err = state.HandleEvent("GUILD_ROLE_DELETE", json.RawMessage(`{"guild_id": "2000", "role_id": "2001"}`))
+	if err != nil {
+		t.Fatalf("Error handling GUILD_ROLE_DELETE: %s", err)
+	}
+	if len(state.GuildRoles(2000)) != 1 {
+		t.Fatalf("Expected role to be removed")
+	}
+
+	err = state.HandleEvent("GUILD_UPDATE", json.RawMessage(`{"id": "2000", "name": "Renamed", "roles": [{"id": "2002", "name": "New"}], "emojis": [{"id": "2100", "name": "wave"}]}`))
+	if err != nil {
+		t.Fatalf("Error handling GUILD_UPDATE: %s", err)
+	}
+	if roles := state.GuildRoles(2000); len(roles) != 1 || roles[0].ID != 2002 || len(state.GuildChannels(2000)) != 2 {
+		t.Fatalf("Expected GUILD_UPDATE to replace the guild's roles & keep its channels, got %+v", roles)
+	}
+
+	err = state.HandleEvent("GUILD_DELETE", json.RawMessage(`{"id": "2000", "unavailable": true}`))
+	if err != nil {
+		t.Fatalf("Error handling GUILD_DELETE: %s", err)
+	}
+	if guild, ok := state.Guild(2000); !ok || !guild.Unavailable || len(guild.Channels) != 2 {
+		t.Fatalf("Expected an outage to mark the guild unavailable instead of removing it, got %+v", guild)
+	}
+
+	err = state.HandleEvent("GUILD_DELETE", json.RawMessage(`{"id": "2000"}`))
+	if err != nil {
+		t.Fatalf("Error handling GUILD_DELETE: %s", err)
+	}
+	if _, ok := state.Guild(2000); ok {
+		t.Fatalf("Expected guild to be removed")
+	}
+	if _, ok := state.Channel(5000); ok {
+		t.Fatalf("Expected guild's channels to be removed")
+	}
+	if _, ok := state.Member(2000, 3000); ok {
+		t.Fatalf("Expected guild's members to be removed")
+	}
+	if len(state.GuildEmojis(2000)) != 0 {
+		t.Fatalf("Expected guild's emojis to be removed")
+	}
+
+	err = state.HandleEvent("GUILD_CREATE", json.RawMessage(`{"id": `))
+	if err == nil {
+		t.Fatalf("Expected malformed event to return an error")
+	}
+	err = state.HandleEvent("TYPING_START", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Expected unhandled event to be ignored, got %s", err)
+	}
+}
+
+func TestStateLimits(t *testing.T) {
+	state := discordapp.NewState(discordapp.StateConfig{MaxMembers: 2})
+	for id := discordapp.Snowflake(1); id <= 3; id++ {
+		state.SetMember(2000, discordapp.Member{User: discordapp.MemberUser{ID: id}})
+	}
+	if _, ok := state.Member(2000, 1); ok {
+		t.Fatalf("Expected least recently stored member to be evicted")
+	}
+	if _, ok := state.Member(2000, 3); !ok {
+		t.Fatalf("Expected most recently stored member to be kept")
+	}
+}
+
+func TestStateReadThrough(t *testing.T) {
+	base, last, _ := interactionServer(t, http.StatusOK, `{"nick": "fetched", "user": {"id": "3000"}}`)
+	state := discordapp.NewState(discordapp.StateConfig{MaxAge: time.Minute})
+	state.SetGuild(discordapp.Guild{ID: 2000, Name: "Cached", ApproximateMemberCount: 5})
+	state.SetGuild(discordapp.Guild{ID: 2001, Name: "Gateway"})
+	bot := &discordapp.Bot{Token: "token", BaseURL: base, State: state}
+
+	guild, err := bot.FetchGuild(2000)
+	if err != nil {
+		t.Fatalf("Error fetching guild: %s", err)
+	}
+	if guild.Name != "Cached" || last.Method != "" {
+		t.Fatalf("Expected cached guild to be returned without a request")
+	}
+	_, err = bot.FetchGuild(2001)
+	if err != nil || last.URL.Path != "/guilds/2001" {
+		t.Fatalf("Expected guild stored without counts to be fetched, got %v", err)
+	}
+
+	member, err := bot.FetchGuildMember(2000, 3000)
+	if err != nil {
+		t.Fatalf("Error fetching member: %s", err)
+	}
+	if member.Nick != "fetched" || last.Method != http.MethodGet {
+		t.Fatalf("Expected missing member to be fetched")
+	}
+	if cached, ok := state.Member(2000, 3000); !ok || cached.Nick != "fetched" {
+		t.Fatalf("Expected fetched member to be stored in the state")
+	}
+
+	stale := discordapp.NewState(discordapp.StateConfig{MaxAge: time.Nanosecond})
+	stale.SetMember(2000, discordapp.Member{Nick: "stale", User: discordapp.MemberUser{ID: 3000}})
+	time.Sleep(time.Millisecond)
+	bot.State = stale
+	member, err = bot.FetchGuildMember(2000, 3000)
+	if err != nil {
+		t.Fatalf("Error fetching member: %s", err)
+	}
+	if member.Nick != "fetched" {
+		t.Fatalf("Expected stale member to be refetched, got %q", member.Nick)
+	}
+}
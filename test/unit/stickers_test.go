@@ -0,0 +1,45 @@
+package unit_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+var testPNG, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR4nGP4z8DwHwAFAAH/iZk9HQAAAABJRU5ErkJggg==")
+
+func TestDetectStickerFormat(t *testing.T) {
+	apng := append([]byte{}, testPNG[:33]...)
+	apng = append(apng, []byte("\x00\x00\x00\x08acTL\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00")...)
+	apng = append(apng, testPNG[33:]...)
+	tests := []struct {
+		name   string
+		file   []byte
+		format int
+	}{
+		{"png", testPNG, discordapp.StickerFormatPNG},
+		{"apng", apng, discordapp.StickerFormatAPNG},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00"), discordapp.StickerFormatGIF},
+		{"lottie", []byte(`{"v":"5.5.2","fr":60,"layers":[]}`), discordapp.StickerFormatLottie},
+	}
+	for _, test := range tests {
+		format, err := discordapp.DetectStickerFormat(test.file)
+		if err != nil {
+			t.Fatalf("Error detecting %s sticker format: %s", test.name, err)
+		}
+		if format != test.format {
+			t.Fatalf("Expected format %d for %s, got %d", test.format, test.name, format)
+		}
+	}
+	_, err := discordapp.DetectStickerFormat([]byte("\xff\xd8\xff\xe0 jpeg"))
+	if !errors.Is(err, discordapp.ErrInvalidStickerFile) {
+		t.Fatalf("Expected ErrInvalidStickerFile for jpeg: %s", err)
+	}
+	_, err = discordapp.DetectStickerFormat(append(append([]byte{}, testPNG...), bytes.Repeat([]byte{0}, discordapp.MaxStickerSize)...))
+	if !errors.Is(err, discordapp.ErrInvalidStickerFile) {
+		t.Fatalf("Expected ErrInvalidStickerFile for oversized file: %s", err)
+	}
+}
@@ -0,0 +1,58 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestGuildTemplateUnmarshal(t *testing.T) {
+	data := []byte(`{
+		"code": "hgM48av5Q69A",
+		"name": "Friends & Family",
+		"description": null,
+		"usage_count": 49605,
+		"creator_id": "132837293881950208",
+		"created_at": "2016-04-30T11:18:25.796000+00:00",
+		"updated_at": "2016-04-30T11:18:25.796000+00:00",
+		"source_guild_id": "678070694164299796",
+		"serialized_source_guild": {
+			"name": "Friends & Family",
+			"verification_level": 0,
+			"afk_channel_id": null,
+			"system_channel_id": 2,
+			"roles": [{"id": 0, "name": "@everyone", "permissions": "104189505", "color": 0}],
+			"channels": [
+				{"id": 1, "name": "Text Channels", "type": 4, "parent_id": null, "permission_overwrites": []},
+				{"id": 2, "name": "general", "type": 0, "parent_id": 1, "permission_overwrites": [{"id": 0, "type": 0, "allow": "0", "deny": "2048"}]}
+			]
+		},
+		"is_dirty": null
+	}`)
+	var template discordapp.GuildTemplate
+	err := json.Unmarshal(data, &template)
+	if err != nil {
+		t.Fatalf("Error unmarshaling template: %s", err)
+	}
+	if template.Code != "hgM48av5Q69A" || template.UsageCount != 49605 {
+		t.Fatalf("Expected template fields to be decoded")
+	}
+	guild := template.SerializedSourceGuild
+	if guild == nil || guild.Name != "Friends & Family" {
+		t.Fatalf("Expected serialized source guild to be decoded")
+	}
+	if guild.SystemChannelID == nil || *guild.SystemChannelID != 2 {
+		t.Fatalf("Expected system channel ID to be decoded from a number")
+	}
+	if len(guild.Roles) != 1 || guild.Roles[0].ID != 0 || guild.Roles[0].Permissions != 104189505 {
+		t.Fatalf("Expected everyone role to be decoded: %+v", guild.Roles)
+	}
+	if len(guild.Channels) != 2 || guild.Channels[1].ID != 2 || guild.Channels[1].ParentID == nil || *guild.Channels[1].ParentID != 1 {
+		t.Fatalf("Expected channels to be decoded: %+v", guild.Channels)
+	}
+	overwrite := guild.Channels[1].PermissionOverwrites[0]
+	if overwrite.ID != 0 || !overwrite.Deny.Has(discordapp.PermissionSendMessages) {
+		t.Fatalf("Expected permission overwrite to be decoded: %+v", overwrite)
+	}
+}
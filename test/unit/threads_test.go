@@ -0,0 +1,29 @@
+package unit_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestArchivedThreadsBefore(t *testing.T) {
+	base, last, _ := interactionServer(t, http.StatusOK, `{"threads": [], "members": [], "has_more": false}`)
+	bot := &discordapp.Bot{Token: "token", BaseURL: base}
+	before := time.Date(2024, 5, 1, 12, 30, 15, 123456000, time.FixedZone("", 2*60*60))
+	_, err := bot.ListPublicArchivedThreads(1000, &before, 50)
+	if err != nil {
+		t.Fatalf("Error listing archived threads: %s", err)
+	}
+	if last.URL.Path != "/channels/1000/threads/archived/public" || last.URL.Query().Get("before") != "2024-05-01T10:30:15.123456Z" || last.URL.Query().Get("limit") != "50" {
+		t.Fatalf("Expected the cursor to keep its fractional seconds, got %s", last.URL)
+	}
+	_, err = bot.ListPrivateArchivedThreads(1000, &before, 0)
+	if err != nil {
+		t.Fatalf("Error listing archived threads: %s", err)
+	}
+	if last.URL.RawQuery != "before=2024-05-01T10%3A30%3A15.123456Z" {
+		t.Fatalf("Expected the cursor to keep its fractional seconds, got %s", last.URL)
+	}
+}
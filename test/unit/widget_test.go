@@ -0,0 +1,58 @@
+package unit_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kodishim/discordapp/discordapp"
+)
+
+func TestWidgetBaseURL(t *testing.T) {
+	var requests []string
+	var widgetAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/oauth2/applications/@me":
+			io.WriteString(w, `{"id": "1000", "name": "discordapp"}`)
+		case "/guilds/2000/widget":
+			io.WriteString(w, `{"enabled": true, "channel_id": "5000"}`)
+		case "/guilds/2000/widget.json":
+			widgetAuthorization = r.Header.Get("Authorization")
+			io.WriteString(w, `{"id": "2000", "name": "guild"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	bot, err := discordapp.NewBotWithConfig("token", discordapp.BotConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Error creating new bot: %s", err)
+	}
+	_, err = bot.FetchGuildWidgetSettings(2000)
+	if err != nil {
+		t.Fatalf("Error fetching widget settings: %s", err)
+	}
+	_, err = bot.ModifyGuildWidget(2000, discordapp.GuildWidgetSettings{Enabled: true}, "")
+	if err != nil {
+		t.Fatalf("Error modifying widget: %s", err)
+	}
+	widget, err := bot.FetchGuildWidget(2000)
+	if err != nil || widget.Name != "guild" {
+		t.Fatalf("Error fetching widget: %v", err)
+	}
+	expected := []string{"GET /oauth2/applications/@me", "GET /guilds/2000/widget", "PATCH /guilds/2000/widget", "GET /guilds/2000/widget.json"}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected every request to be sent to the bot's BaseURL, got %v", requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Fatalf("Expected every request to be sent to the bot's BaseURL, got %v", requests)
+		}
+	}
+	if widgetAuthorization != "" {
+		t.Fatalf("Expected the public widget to be fetched without the bot's token, got %q", widgetAuthorization)
+	}
+}